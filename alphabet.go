@@ -0,0 +1,130 @@
+package frac
+
+import "fmt"
+
+var (
+	/*
+		Digits "0-9a-zA-Z", supporting radixes up to 62. Extends the default
+		alphabet, so for radixes up to 36 the output is the same as with no
+		alphabet, but parsing is case-sensitive.
+	*/
+	AlphabetBase62 = mustAlphabet(`0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ`, false, nil)
+
+	/*
+		Crockford's base32 alphabet, for use with radix 32. Parsing is
+		case-insensitive and treats "O" as "0" and "I" and "L" as "1".
+		Formatting uses uppercase.
+	*/
+	AlphabetCrockford = mustAlphabet(`0123456789ABCDEFGHJKMNPQRSTVWXYZ`, true, map[byte]byte{'O': '0', 'I': '1', 'L': '1'})
+)
+
+/*
+Custom set of digits for `ParseOpt` and `FormatOpt`, where the digit at index N
+represents the value N. Supports radixes up to the length of the alphabet,
+which may be as large as 64. Must be created via `NewAlphabet`.
+*/
+type Alphabet struct {
+	digits string
+	values [256]byte
+}
+
+/*
+Creates an alphabet from the given digits, validating them. Digits must be
+unique printable ASCII characters other than "+", "-" and ".", which have
+special meaning in numbers.
+
+When `fold` is true, parsing is case-insensitive: each letter is also accepted
+in the opposite case. Digits must remain unique after folding.
+
+`aliases` maps additional characters accepted when parsing to the digits they
+stand for. For example, Crockford's base32 accepts "O" as "0". Aliases are
+subject to folding, and must not collide with digits.
+*/
+func NewAlphabet(digits string, fold bool, aliases map[byte]byte) (*Alphabet, error) {
+	if !(uint(len(digits)) >= radixMin && uint(len(digits)) <= radixMaxAlphabet) {
+		return nil, fmt.Errorf(
+			`unable to create alphabet %q: length %v is outside of range [%v,%v]`,
+			digits, len(digits), radixMin, radixMaxAlphabet,
+		)
+	}
+
+	var out Alphabet
+	out.digits = digits
+	for ind := range out.values {
+		out.values[ind] = unDigit
+	}
+
+	for ind, char := range []byte(digits) {
+		if !isAlphabetChar(char) {
+			return nil, fmt.Errorf(`unable to create alphabet %q: invalid digit %q`, digits, runeAt(digits, ind))
+		}
+		err := out.add(char, byte(ind), fold)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for alias, char := range aliases {
+		if !isAlphabetChar(alias) {
+			return nil, fmt.Errorf(`unable to create alphabet %q: invalid alias %q`, digits, alias)
+		}
+		digit := out.values[char]
+		if digit == unDigit {
+			return nil, fmt.Errorf(`unable to create alphabet %q: alias %q refers to non-digit %q`, digits, alias, char)
+		}
+		err := out.add(alias, digit, fold)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &out, nil
+}
+
+// Returns the digits of the alphabet, in order.
+func (self *Alphabet) String() string { return self.digits }
+
+// Returns the amount of digits, which is also the largest supported radix.
+func (self *Alphabet) Len() int { return len(self.digits) }
+
+func (self *Alphabet) add(char byte, digit byte, fold bool) error {
+	err := self.set(char, digit)
+	if err != nil {
+		return err
+	}
+	if fold && isLetter(char) {
+		return self.set(char^('a'-'A'), digit)
+	}
+	return nil
+}
+
+func (self *Alphabet) set(char byte, digit byte) error {
+	if self.values[char] != unDigit {
+		return fmt.Errorf(`unable to create alphabet %q: duplicate digit %q`, self.digits, char)
+	}
+	self.values[char] = digit
+	return nil
+}
+
+func (self *Alphabet) radixMax() uint {
+	if self == nil {
+		return radixMax
+	}
+	return uint(len(self.digits))
+}
+
+func mustAlphabet(digits string, fold bool, aliases map[byte]byte) *Alphabet {
+	out, err := NewAlphabet(digits, fold, aliases)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func isAlphabetChar(char byte) bool {
+	return char > ' ' && char < 0x7f && char != '+' && char != '-' && char != '.'
+}
+
+func isLetter(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
@@ -0,0 +1,80 @@
+package frac
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestAlphabetBase62(*testing.T) {
+	opt := FormatOpt{Frac: 1, Radix: 62, Alphabet: AlphabetBase62}
+	testFormatOpt(opt, 61*62+10, `Z.a`)
+	testFormatOpt(opt, -(61*62 + 10), `-Z.a`)
+	testFormatOpt(FormatOpt{Radix: 62, Alphabet: AlphabetBase62}, math.MaxInt64, `aZl8N0y58M7`)
+	testFormatOpt(FormatOpt{Radix: 36, Alphabet: AlphabetBase62}, 35, `z`)
+
+	testParseOpt(ParseOpt{Frac: 1, Radix: 62, Alphabet: AlphabetBase62}, `Z.a`, 61*62+10)
+	testParseOpt(ParseOpt{Frac: 1, Radix: 62, Alphabet: AlphabetBase62}, `-Z.a`, -(61*62 + 10))
+	testParseOpt(ParseOpt{Radix: 62, Alphabet: AlphabetBase62}, `aZl8N0y58M7`, math.MaxInt64)
+	testParseOptErr(ParseOpt{Radix: 36, Alphabet: AlphabetBase62}, `Z`, `non-digit character 'Z'`)
+
+	testParseOptErr(ParseOpt{Radix: 63, Alphabet: AlphabetBase62}, `0`, `unsupported radix 63`)
+	testFormatOptErr(FormatOpt{Radix: 63, Alphabet: AlphabetBase62}, 0, `unsupported radix 63`)
+}
+
+func TestAlphabetCrockford(*testing.T) {
+	testFormatOpt(FormatOpt{Frac: 1, Radix: 32, Alphabet: AlphabetCrockford}, 31*32+1, `Z.1`)
+	testFormatOpt(FormatOpt{Frac: 1, Radix: 32, Alphabet: AlphabetCrockford}, 18*32, `J`)
+
+	opt := ParseOpt{Frac: 1, Radix: 32, Alphabet: AlphabetCrockford}
+	testParseOpt(opt, `Z.1`, 31*32+1)
+	testParseOpt(opt, `z.1`, 31*32+1)
+	testParseOpt(opt, `z.I`, 31*32+1)
+	testParseOpt(opt, `z.l`, 31*32+1)
+	testParseOpt(opt, `1O.o`, 32*32)
+	testParseOptErr(opt, `U`, `non-digit character 'U'`)
+	testParseOptErr(opt, `u`, `non-digit character 'u'`)
+}
+
+func TestAlphabetCustom(*testing.T) {
+	alpha, err := NewAlphabet(`ab`, false, nil)
+	if err != nil {
+		panic(err)
+	}
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 2, Alphabet: alpha}, 0b101_01, `bab.ab`)
+	testParseOpt(ParseOpt{Frac: 2, Radix: 2, Alphabet: alpha}, `-bab.ab`, -0b101_01)
+	testParseOptErr(ParseOpt{Frac: 2, Radix: 2, Alphabet: alpha}, `101`, `non-digit character '1'`)
+
+	alpha64 := `0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz_~`
+	alpha, err = NewAlphabet(alpha64, false, nil)
+	if err != nil {
+		panic(err)
+	}
+	testFormatOpt(FormatOpt{Frac: 1, Radix: 64, Alphabet: alpha}, 63*64+62, `~._`)
+	testParseOpt(ParseOpt{Frac: 1, Radix: 64, Alphabet: alpha}, `~._`, 63*64+62)
+
+	testAlphabetErr(`0`, false, nil, `length 1 is outside of range [2,64]`)
+	testAlphabetErr(alpha64+`!`, false, nil, `length 65 is outside of range [2,64]`)
+	testAlphabetErr(`010`, false, nil, `duplicate digit '0'`)
+	testAlphabetErr(`0aA`, true, nil, `duplicate digit 'A'`)
+	testAlphabetErr(`01+`, false, nil, `invalid digit '+'`)
+	testAlphabetErr(`01-`, false, nil, `invalid digit '-'`)
+	testAlphabetErr(`01.`, false, nil, `invalid digit '.'`)
+	testAlphabetErr(`01 `, false, nil, `invalid digit ' '`)
+	testAlphabetErr(`01é`, false, nil, `invalid digit 'é'`)
+	testAlphabetErr(`01`, false, map[byte]byte{'o': '2'}, `alias 'o' refers to non-digit '2'`)
+	testAlphabetErr(`01`, false, map[byte]byte{'1': '0'}, `duplicate digit '1'`)
+	testAlphabetErr(`01`, false, map[byte]byte{'.': '0'}, `invalid alias '.'`)
+	testAlphabetErr(`0aB`, true, map[byte]byte{'b': '0'}, `duplicate digit 'b'`)
+}
+
+func testAlphabetErr(digits string, fold bool, aliases map[byte]byte, msg string) {
+	res, err := NewAlphabet(digits, fold, aliases)
+	if err == nil {
+		panic(fmt.Errorf(`expected creating alphabet %q to fail; instead got %q`, digits, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from creating alphabet %q to contain %q, got %q`, digits, msg, err))
+	}
+}
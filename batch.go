@@ -0,0 +1,88 @@
+package frac
+
+import "fmt"
+
+/*
+Parses each string from `srcs` into the element of `dst` at the same index,
+for converting columnar data. `dst` must be at least as long as `srcs`. The
+options are validated once for the whole batch rather than once per value.
+Shortcut for `ParseOpt.ParseAll`.
+
+Stops at the first invalid input, returning its index and the error. Elements
+of `dst` before that index are filled. When the error doesn't belong to any
+particular input, such as for an unsupported radix, the index is -1. On
+success, returns -1 and nil.
+*/
+func ParseAll(dst []int64, srcs []string, frac uint, radix uint) (errIdx int, err error) {
+	return ParseOpt{Frac: frac, Radix: radix}.ParseAll(dst, srcs)
+}
+
+// Same as `ParseAll` but uses the options.
+func (self ParseOpt) ParseAll(dst []int64, srcs []string) (errIdx int, err error) {
+	err = self.validate()
+	if err != nil {
+		return -1, fmt.Errorf(`unable to parse numbers: %w`, err)
+	}
+
+	if len(dst) < len(srcs) {
+		return -1, fmt.Errorf(
+			`unable to parse numbers: destination length %v is less than source length %v`,
+			len(dst), len(srcs),
+		)
+	}
+
+	dst = dst[:len(srcs)]
+
+	if !self.plain() {
+		for ind, src := range srcs {
+			dst[ind], err = self.parse(src)
+			if err != nil {
+				return ind, err
+			}
+		}
+		return -1, nil
+	}
+
+	plain := self.plainParser()
+	for ind, src := range srcs {
+		num, ok := plain.parse(src)
+		if !ok {
+			num, err = self.parse(src)
+			if err != nil {
+				return ind, err
+			}
+		}
+		dst[ind] = num
+	}
+	return -1, nil
+}
+
+/*
+Formats each number from `nums`, appending the resulting text to `buf`. After
+each number, appends the resulting length of `buf` to `offsets`, so that the
+Nth number occupies `buf[offsets[N-1]:offsets[N]]`. To get Arrow-style offsets
+where the Nth number occupies `buf[offsets[N]:offsets[N+1]]`, pass offsets that
+end with the initial length of `buf`, such as `[]int{0}` for an empty buffer.
+The options are validated once for the whole batch rather than once per value.
+Shortcut for `FormatOpt.AppendAll`.
+
+When there's an error, the buffer and the offsets are returned as-is with no
+hidden modifications.
+*/
+func AppendAll(buf []byte, offsets []int, nums []int64, frac uint, radix uint) ([]byte, []int, error) {
+	return FormatOpt{Frac: frac, Radix: radix}.AppendAll(buf, offsets, nums)
+}
+
+// Same as `AppendAll` but uses the options.
+func (self FormatOpt) AppendAll(buf []byte, offsets []int, nums []int64) ([]byte, []int, error) {
+	err := self.Validate()
+	if err != nil {
+		return buf, offsets, fmt.Errorf(`unable to format numbers: %w`, err)
+	}
+
+	for _, num := range nums {
+		buf = self.append(buf, num)
+		offsets = append(offsets, len(buf))
+	}
+	return buf, offsets, nil
+}
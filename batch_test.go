@@ -0,0 +1,203 @@
+package frac
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	benchSrcs = func() (out []string) {
+		for ind := range counter(1024) {
+			out = append(out, strconv.Itoa(ind*7919-4_000_000)+`.`+strconv.Itoa(ind%100))
+		}
+		return
+	}()
+	benchNums = func() (out []int64) {
+		for ind := range counter(1024) {
+			out = append(out, int64(ind*7919-4_000_000))
+		}
+		return
+	}()
+)
+
+func BenchmarkParseAll(b *testing.B) {
+	dst := make([]int64, len(benchSrcs))
+	b.ResetTimer()
+
+	for range counter(b.N) {
+		_, err := ParseAll(dst, benchSrcs, 2, 10)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseLoop(b *testing.B) {
+	dst := make([]int64, len(benchSrcs))
+	b.ResetTimer()
+
+	for range counter(b.N) {
+		for ind, src := range benchSrcs {
+			var err error
+			dst[ind], err = Parse(src, 2, 10)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkAppendAll(b *testing.B) {
+	buf := make([]byte, 0, 16*len(benchNums))
+	offsets := make([]int, 0, len(benchNums))
+	b.ResetTimer()
+
+	for range counter(b.N) {
+		var err error
+		buf, offsets, err = AppendAll(buf[:0], offsets[:0], benchNums, 2, 10)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAppendLoop(b *testing.B) {
+	buf := make([]byte, 0, 16*len(benchNums))
+	offsets := make([]int, 0, len(benchNums))
+	b.ResetTimer()
+
+	for range counter(b.N) {
+		buf, offsets = buf[:0], offsets[:0]
+		for _, num := range benchNums {
+			var err error
+			buf, err = Append(buf, num, 2, 10)
+			if err != nil {
+				b.Fatal(err)
+			}
+			offsets = append(offsets, len(buf))
+		}
+	}
+}
+
+func TestParseAll(*testing.T) {
+	dst := make([]int64, 4)
+	testParseAll(dst, []string{`1`, `-2.5`, `0.01`}, -1, ``)
+	testEqual(dst, []int64{1_00, -2_50, 1, 0})
+
+	dst = make([]int64, 3)
+	testParseAll(dst, []string{`1`, `2.555`, `3`}, 1, `exponent exceeds`)
+	testEqual(dst, []int64{1_00, 0, 0})
+
+	testParseAll(nil, nil, -1, ``)
+	testParseAll(make([]int64, 1), []string{`1`, `2`}, -1, `destination length 1 is less than source length 2`)
+
+	idx, err := ParseOpt{Frac: 2, Radix: 37}.ParseAll(make([]int64, 1), []string{`1`})
+	if idx != -1 || err == nil || err.Error() != `unable to parse numbers: unsupported radix 37` {
+		panic(fmt.Errorf(`unexpected ParseAll result: %v, %v`, idx, err))
+	}
+
+	idx, err = ParseOpt{Frac: 2, Radix: 10, Lenient: true}.ParseAll(make([]int64, 2), []string{` 1`, ` `})
+	if idx != 1 || err == nil {
+		panic(fmt.Errorf(`unexpected ParseAll result: %v, %v`, idx, err))
+	}
+}
+
+// `Parse` and `ParseAll` use `plainParser` where possible; results must match
+// the general path.
+func TestParsePlain(*testing.T) {
+	srcs := []string{
+		``, `+`, `-`, `.`, `.5`, `1.`, `-1.`, `1..2`, `1.2.3`, `--1`, `+-1`, ` 1`, `1 `,
+		`0`, `-0`, `+0`, `00`, `0.0`, `0.00`, `0.000`, `1.500`, `1.501`, `1.5`, `-1.25`,
+		`12`, `ff`, `FF`, `fF.8`, `z`, `Z`, `_1`, `0x1`, `1e5`,
+		`9223372036854775807`, `9223372036854775808`, `-9223372036854775808`,
+		`-9223372036854775809`, `92233720368547758.07`, `-92233720368547758.08`,
+		`92233720368547758.08`, `99999999999999999999`, `7fffffffffffffff`,
+		`-8000000000000000`, `8000000000000000`, `111111111111111111111111111111111111111111111111111111111111111`,
+		`1.0`, `1.000000`, `-1.2300000000000000000000000000000000000000000000000000000000000000000`,
+		`1.50000001`, `0.000000000000000000000000000000001`,
+	}
+	srcs = append(srcs, benchSrcs...)
+
+	for _, opt := range []ParseOpt{
+		{Frac: 0, Radix: 10},
+		{Frac: 2, Radix: 10},
+		{Frac: 30, Radix: 10},
+		{Frac: 1, Radix: 16},
+		{Frac: 1, Radix: 16, Case: CaseLower},
+		{Frac: 1, Radix: 16, Case: CaseUpper},
+		{Frac: 0, Radix: 2},
+		{Frac: 2, Radix: 36},
+		{Frac: 1, Radix: 62, Alphabet: AlphabetBase62},
+	} {
+		for _, src := range srcs {
+			exp, expErr := opt.parse(src)
+
+			act, err := opt.Parse(src)
+			if fmt.Sprint(err) != fmt.Sprint(expErr) || act != exp {
+				panic(fmt.Errorf(
+					`Parse mismatch for %q (%+v): expected %v, %v; got %v, %v`,
+					src, opt, exp, expErr, act, err,
+				))
+			}
+
+			var dst [1]int64
+			_, err = opt.ParseAll(dst[:], []string{src})
+			if fmt.Sprint(err) != fmt.Sprint(expErr) || dst[0] != exp {
+				panic(fmt.Errorf(
+					`ParseAll mismatch for %q (%+v): expected %v, %v; got %v, %v`,
+					src, opt, exp, expErr, dst[0], err,
+				))
+			}
+		}
+	}
+}
+
+func TestAppendAll(*testing.T) {
+	buf, offsets, err := AppendAll([]byte(`>`), []int{1}, []int64{1_00, -2_50, 1, 0}, 2, 10)
+	if err != nil {
+		panic(err)
+	}
+	testEqual(string(buf), `>1-2.50.010`)
+	testEqual(offsets, []int{1, 2, 6, 10, 11})
+
+	buf, offsets, err = FormatOpt{Frac: 2, Radix: 10, MinFrac: 2}.AppendAll(nil, nil, []int64{1_00, -2_50})
+	if err != nil {
+		panic(err)
+	}
+	testEqual(string(buf), `1.00-2.50`)
+	testEqual(offsets, []int{4, 9})
+
+	buf, offsets, err = AppendAll([]byte(`>`), []int{1}, []int64{1}, 2, 37)
+	if err == nil || err.Error() != `unable to format numbers: unsupported radix 37` {
+		panic(fmt.Errorf(`unexpected AppendAll error: %v`, err))
+	}
+	testEqual(string(buf), `>`)
+	testEqual(offsets, []int{1})
+
+	buf, offsets, err = AppendAll(nil, []int{0}, benchNums, 2, 10)
+	if err != nil {
+		panic(err)
+	}
+	for ind, num := range benchNums {
+		exp, err := FormatDec(num, 2)
+		if err != nil {
+			panic(err)
+		}
+		testEqual(string(buf[offsets[ind]:offsets[ind+1]]), exp)
+	}
+}
+
+func testParseAll(dst []int64, srcs []string, expIdx int, msg string) {
+	idx, err := ParseAll(dst, srcs, 2, 10)
+	if idx != expIdx {
+		panic(fmt.Errorf(`expected ParseAll to fail at index %v, got %v (%v)`, expIdx, idx, err))
+	}
+	if msg == `` && err != nil {
+		panic(fmt.Errorf(`expected ParseAll to succeed, got %v`, err))
+	}
+	if msg != `` && (err == nil || !strings.Contains(err.Error(), msg)) {
+		panic(fmt.Errorf(`expected ParseAll error to contain %q, got %v`, msg, err))
+	}
+}
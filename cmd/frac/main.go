@@ -0,0 +1,385 @@
+/*
+Command-line tool for parsing, formatting, converting and validating
+fractional numbers with `github.com/mitranim/frac`.
+
+Usage:
+
+	frac parse    [-frac N] [-radix N] [-json] [file ...]
+	frac format   [-frac N] [-radix N] [-min-frac N] [-json] [file ...]
+	frac convert  [-from-frac N] [-from-radix N] [-to-frac N] [-to-radix N] [-json] [file ...]
+	frac validate [-frac N] [-radix N] [-column N] [-tsv] [-header] [-json] [file ...]
+	frac transcode -columns N[,N...] [-radix N] [-from-frac N] [-from-scaled] [-to-frac N] [-to-scaled] [-tsv] [-header] [-keep] [-json] [file ...]
+
+Reads the given files, or stdin when there are none; "-" also means stdin.
+
+"parse", "format" and "convert" treat each input line as one value. "parse"
+converts fractional strings such as "-12.50" into scaled integers such as
+-1250. "format" does the opposite. "convert" changes the precision and radix of
+fractional strings, failing rather than rounding when the value can't be
+represented exactly. Results are written to stdout, one per line; failures are
+reported to stderr as "file:line: kind: message".
+
+"validate" reads CSV, or TSV with "-tsv", and checks one column without
+producing any output for valid rows. Each invalid row is reported to stdout as
+a CSV record "file,row,kind,input,message". Rows and columns are numbered from
+1.
+
+"transcode" reads CSV, or TSV with "-tsv", and writes it to stdout, converting
+the given columns between fractional strings such as "12.50" and scaled
+integers such as "1250" ("-from-scaled", "-to-scaled"), optionally changing
+the precision; see package `fraccsv`. Rows with invalid values are omitted,
+or copied unchanged with "-keep", and reported to stderr as
+"file:row: kind: message".
+
+With "-json", every result or failure is written to stdout as a JSON object per
+line, with the fields "file", "line", "input", and either "output" or "kind"
+and "error". "validate" and "transcode" also include "column". For
+"transcode", failures are written to stderr instead.
+
+Failure kinds are "syntax", "precision", "range", "radix" and "other"; see the
+corresponding errors such as `frac.ErrSyntax`.
+
+Exits with status 1 when any input is invalid, and 2 for usage errors.
+*/
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mitranim/frac"
+)
+
+const usage = `usage:
+	frac parse    [-frac N] [-radix N] [-json] [file ...]
+	frac format   [-frac N] [-radix N] [-min-frac N] [-json] [file ...]
+	frac convert  [-from-frac N] [-from-radix N] [-to-frac N] [-to-radix N] [-json] [file ...]
+	frac validate [-frac N] [-radix N] [-column N] [-tsv] [-header] [-json] [file ...]
+	frac transcode -columns N[,N...] [-radix N] [-from-frac N] [-from-scaled] [-to-frac N] [-to-scaled] [-tsv] [-header] [-keep] [-json] [file ...]
+`
+
+const (
+	exitOk      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	cmd := &command{stdin: stdin, stderr: stderr}
+	flags := flag.NewFlagSet(`frac `+args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.BoolVar(&cmd.json, `json`, false, `write results as JSON lines`)
+
+	var conv func(string) (string, error)
+
+	switch args[0] {
+	case `parse`:
+		opt := frac.ParseOpt{}
+		flags.UintVar(&opt.Frac, `frac`, 2, `fractional precision`)
+		flags.UintVar(&opt.Radix, `radix`, 10, `radix`)
+		conv = func(src string) (string, error) {
+			num, err := opt.Parse(src)
+			return strconv.FormatInt(num, 10), err
+		}
+
+	case `format`:
+		opt := frac.FormatOpt{}
+		flags.UintVar(&opt.Frac, `frac`, 2, `fractional precision`)
+		flags.UintVar(&opt.Radix, `radix`, 10, `radix`)
+		flags.UintVar(&opt.MinFrac, `min-frac`, 0, `minimum amount of fractional digits`)
+		conv = func(src string) (string, error) {
+			num, err := strconv.ParseInt(src, 10, 64)
+			if err != nil {
+				return ``, err
+			}
+			return opt.Format(num)
+		}
+
+	case `convert`:
+		var opt converter
+		flags.UintVar(&opt.from.Frac, `from-frac`, 2, `input fractional precision`)
+		flags.UintVar(&opt.from.Radix, `from-radix`, 10, `input radix`)
+		flags.UintVar(&opt.to.Frac, `to-frac`, 2, `output fractional precision`)
+		flags.UintVar(&opt.to.Radix, `to-radix`, 10, `output radix`)
+		conv = func(src string) (string, error) { return opt.convert(src) }
+
+	case `validate`:
+		return cmd.validate(flags, args[1:], stdout)
+
+	case `transcode`:
+		return cmd.transcode(flags, args[1:], stdout)
+
+	case `help`, `-h`, `-help`, `--help`:
+		fmt.Fprint(stdout, usage)
+		return exitOk
+
+	default:
+		fmt.Fprintf(stderr, "frac: unknown command %q\n%v", args[0], usage)
+		return exitUsage
+	}
+
+	if flags.Parse(args[1:]) != nil {
+		return exitUsage
+	}
+	return cmd.lines(flags.Args(), stdout, conv)
+}
+
+type command struct {
+	stdin   io.Reader
+	stderr  io.Writer
+	json    bool
+	invalid bool
+}
+
+// Machine-readable description of one result or failure.
+type result struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column,omitempty"`
+	Input  string `json:"input"`
+	Output string `json:"output,omitempty"`
+	Kind   string `json:"kind,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (self *command) lines(paths []string, out io.Writer, conv func(string) (string, error)) int {
+	buf := bufio.NewWriter(out)
+	enc := json.NewEncoder(buf)
+
+	code := self.each(paths, func(path string, file io.Reader) error {
+		scanner := bufio.NewScanner(file)
+		line := 0
+
+		for scanner.Scan() {
+			line++
+			src := strings.TrimSuffix(scanner.Text(), "\r")
+			res := result{File: path, Line: line, Input: src}
+
+			output, err := conv(src)
+			if err != nil {
+				self.invalid = true
+				res.Kind, res.Error = errKind(err), err.Error()
+			} else {
+				res.Output = output
+			}
+
+			if self.json {
+				err = enc.Encode(res)
+			} else if res.Kind != `` {
+				_, err = fmt.Fprintf(self.stderr, "%v:%v: %v: %v\n", path, line, res.Kind, res.Error)
+			} else {
+				_, err = fmt.Fprintln(buf, output)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+	})
+
+	return self.finish(code, buf)
+}
+
+func (self *command) validate(flags *flag.FlagSet, args []string, out io.Writer) int {
+	opt := frac.ParseOpt{}
+	var column uint
+	var tsv, header bool
+
+	flags.UintVar(&opt.Frac, `frac`, 2, `fractional precision`)
+	flags.UintVar(&opt.Radix, `radix`, 10, `radix`)
+	flags.UintVar(&column, `column`, 1, `column to validate, starting with 1`)
+	flags.BoolVar(&tsv, `tsv`, false, `read tab-separated values rather than CSV`)
+	flags.BoolVar(&header, `header`, false, `skip the first row`)
+
+	if flags.Parse(args) != nil {
+		return exitUsage
+	}
+	if column == 0 {
+		fmt.Fprintln(self.stderr, `frac: column numbers start with 1`)
+		return exitUsage
+	}
+
+	buf := bufio.NewWriter(out)
+	enc := json.NewEncoder(buf)
+	rep := csv.NewWriter(buf)
+
+	code := self.each(flags.Args(), func(path string, file io.Reader) error {
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.ReuseRecord = true
+		if tsv {
+			reader.Comma = '\t'
+			reader.LazyQuotes = true
+		}
+
+		for row := 1; ; row++ {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if header && row == 1 {
+				continue
+			}
+
+			res := result{File: path, Line: row, Column: int(column)}
+			if int(column) > len(record) {
+				res.Kind, res.Error = `other`, fmt.Sprintf(`row has %v columns, expected at least %v`, len(record), column)
+			} else {
+				res.Input = record[column-1]
+				_, err = opt.Parse(res.Input)
+				if err == nil {
+					continue
+				}
+				res.Kind, res.Error = errKind(err), err.Error()
+			}
+
+			self.invalid = true
+			if self.json {
+				err = enc.Encode(res)
+			} else {
+				err = rep.Write([]string{path, strconv.Itoa(row), res.Kind, res.Input, res.Error})
+				rep.Flush()
+			}
+			if err != nil {
+				return err
+			}
+		}
+	})
+
+	return self.finish(code, buf)
+}
+
+// Calls the function for each file, or for stdin when there are no paths.
+func (self *command) each(paths []string, fun func(string, io.Reader) error) int {
+	if len(paths) == 0 {
+		paths = []string{`-`}
+	}
+
+	for _, path := range paths {
+		err := self.file(path, fun)
+		if err != nil {
+			fmt.Fprintf(self.stderr, "frac: %v\n", err)
+			return exitInvalid
+		}
+	}
+	return exitOk
+}
+
+func (self *command) file(path string, fun func(string, io.Reader) error) error {
+	if path == `-` {
+		return fun(path, self.stdin)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	err = fun(path, file)
+	if err != nil {
+		return fmt.Errorf(`%v: %w`, path, err)
+	}
+	return nil
+}
+
+func (self *command) finish(code int, buf *bufio.Writer) int {
+	err := buf.Flush()
+	if err != nil {
+		fmt.Fprintf(self.stderr, "frac: %v\n", err)
+		return exitInvalid
+	}
+	if code == exitOk && self.invalid {
+		return exitInvalid
+	}
+	return code
+}
+
+/*
+Converts fractional strings between precisions and radixes. The value is
+rescaled exactly via `big.Int`; a value that would need rounding is rejected.
+*/
+type converter struct {
+	from frac.ParseOpt
+	to   frac.FormatOpt
+}
+
+func (self converter) convert(src string) (string, error) {
+	num, err := self.from.Parse(src)
+	if err != nil {
+		return ``, err
+	}
+
+	num, err = self.rescale(num)
+	if err != nil {
+		return ``, fmt.Errorf(`unable to convert %q: %w`, src, err)
+	}
+	return self.to.Format(num)
+}
+
+func (self converter) rescale(num int64) (int64, error) {
+	if self.from.Frac == self.to.Frac && self.from.Radix == self.to.Radix {
+		return num, nil
+	}
+
+	// Validates the output options before rescaling, so that an unsupported
+	// radix is reported as such.
+	err := self.to.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	val := big.NewInt(num)
+	val.Mul(val, bigPow(self.to.Radix, self.to.Frac))
+
+	var rem big.Int
+	val.QuoRem(val, bigPow(self.from.Radix, self.from.Frac), &rem)
+
+	if rem.Sign() != 0 {
+		return 0, fmt.Errorf(`%w: value can't be represented exactly in radix %v with fraction %v`, frac.ErrPrecision, self.to.Radix, self.to.Frac)
+	}
+	if !val.IsInt64() {
+		return 0, fmt.Errorf(`%w: value exceeds int64 in radix %v with fraction %v`, frac.ErrRange, self.to.Radix, self.to.Frac)
+	}
+	return val.Int64(), nil
+}
+
+func bigPow(radix uint, exp uint) *big.Int {
+	return new(big.Int).Exp(big.NewInt(int64(radix)), big.NewInt(int64(exp)), nil)
+}
+
+func errKind(err error) string {
+	switch {
+	case errors.Is(err, frac.ErrSyntax), errors.Is(err, strconv.ErrSyntax):
+		return `syntax`
+	case errors.Is(err, frac.ErrPrecision):
+		return `precision`
+	case errors.Is(err, frac.ErrRange), errors.Is(err, strconv.ErrRange):
+		return `range`
+	case errors.Is(err, frac.ErrRadix):
+		return `radix`
+	default:
+		return `other`
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(*testing.T) {
+	testRun(
+		[]string{`parse`},
+		"12.50\n-3\r\n1.255\nabc\n\n",
+		exitInvalid,
+		"1250\n-300\n",
+		`-:3: precision: unable to parse "1.255" as number (radix 10, fraction 2): exponent exceeds allotted fractional precision
+-:4: syntax: unable to parse "abc" as number (radix 10, fraction 2): found non-digit character 'a'
+-:5: syntax: unable to parse empty input as number
+`,
+	)
+
+	testRun([]string{`parse`, `-frac`, `1`, `-radix`, `16`}, "ff.8\n", exitOk, "4088\n", ``)
+	testRun([]string{`parse`, `-frac`, `0`}, "9223372036854775808\n", exitInvalid, ``, `-:1: range: unable to parse "9223372036854775808" as number: overflow of int64`+"\n")
+	testRun([]string{`parse`, `-radix`, `37`}, "1\n", exitInvalid, ``, `-:1: radix: unable to parse "1" as number: unsupported radix 37`+"\n")
+
+	testRun(
+		[]string{`parse`, `-json`},
+		"1.5\n1.555\n",
+		exitInvalid,
+		`{"file":"-","line":1,"input":"1.5","output":"150"}
+{"file":"-","line":2,"input":"1.555","kind":"precision","error":"unable to parse \"1.555\" as number (radix 10, fraction 2): exponent exceeds allotted fractional precision"}
+`,
+		``,
+	)
+}
+
+func TestFormat(*testing.T) {
+	testRun([]string{`format`}, "1250\n-5\n0\n", exitOk, "12.5\n-0.05\n0\n", ``)
+	testRun([]string{`format`, `-min-frac`, `2`}, "1250\n", exitOk, "12.50\n", ``)
+	testRun([]string{`format`, `-frac`, `1`, `-radix`, `16`}, "4088\n", exitOk, "ff.8\n", ``)
+	testRun([]string{`format`}, "1.5\n", exitInvalid, ``, `-:1: syntax: strconv.ParseInt: parsing "1.5": invalid syntax`+"\n")
+	testRun([]string{`format`}, "9223372036854775808\n", exitInvalid, ``, `-:1: range: strconv.ParseInt: parsing "9223372036854775808": value out of range`+"\n")
+	testRun([]string{`format`, `-radix`, `1`}, "1\n", exitInvalid, ``, `-:1: radix: unable to format 1: unsupported radix 1`+"\n")
+}
+
+func TestConvert(*testing.T) {
+	testRun([]string{`convert`, `-to-frac`, `4`}, "12.5\n", exitOk, "12.5\n", ``)
+	testRun([]string{`convert`, `-to-radix`, `16`, `-to-frac`, `1`}, "-255.5\n", exitOk, "-ff.8\n", ``)
+	testRun([]string{`convert`, `-from-radix`, `2`, `-from-frac`, `3`}, "0.111\n", exitInvalid, ``,
+		`-:1: precision: unable to convert "0.111": excess precision: value can't be represented exactly in radix 10 with fraction 2`+"\n")
+	testRun([]string{`convert`, `-from-frac`, `0`, `-to-frac`, `18`}, "10\n", exitInvalid, ``,
+		`-:1: range: unable to convert "10": out of range: value exceeds int64 in radix 10 with fraction 18`+"\n")
+	testRun([]string{`convert`, `-to-radix`, `99`}, "1\n", exitInvalid, ``, `-:1: radix: unable to convert "1": unsupported radix 99`+"\n")
+}
+
+func TestValidate(*testing.T) {
+	src := "name,amount\nfoo,1.50\nbar,1.555\nbaz\nqux,\"1,5\"\n"
+
+	testRun([]string{`validate`, `-column`, `2`, `-header`}, src, exitInvalid, `-,3,precision,1.555,"unable to parse ""1.555"" as number (radix 10, fraction 2): exponent exceeds allotted fractional precision"
+-,4,other,,"row has 1 columns, expected at least 2"
+-,5,syntax,"1,5","unable to parse ""1,5"" as number (radix 10, fraction 2): found non-digit character ','"
+`, ``)
+
+	testRun([]string{`validate`, `-column`, `2`, `-tsv`}, "foo\t1.5\nbar\t2\n", exitOk, ``, ``)
+
+	testRun([]string{`validate`, `-column`, `2`, `-tsv`, `-json`}, "foo\t1.5\nbar\t-\n", exitInvalid,
+		`{"file":"-","line":2,"column":2,"input":"-","kind":"syntax","error":"unable to parse \"-\" as number (radix 10, fraction 2): unexpected end of input"}`+"\n", ``)
+
+	testRun([]string{`validate`, `-column`, `0`}, ``, exitUsage, ``, "frac: column numbers start with 1\n")
+}
+
+func TestTranscode(*testing.T) {
+	src := "id,amount\n1,12.50\n2,1.255\n3,-3\n"
+
+	testRun([]string{`transcode`, `-columns`, `2`, `-header`, `-to-frac`, `4`, `-to-scaled`}, src, exitInvalid,
+		"id,amount\n1,125000\n3,-30000\n",
+		`-:3: precision: column 2: unable to parse "1.255" as number (radix 10, fraction 2): exponent exceeds allotted fractional precision`+"\n")
+
+	testRun([]string{`transcode`, `-columns`, `2`, `-header`, `-keep`, `-json`}, src, exitInvalid,
+		"id,amount\n1,12.5\n2,1.255\n3,-3\n",
+		`{"file":"-","line":3,"column":2,"input":"1.255","kind":"precision","error":"unable to parse \"1.255\" as number (radix 10, fraction 2): exponent exceeds allotted fractional precision"}`+"\n")
+
+	testRun([]string{`transcode`, `-columns`, `1, 2`, `-tsv`, `-from-scaled`, `-from-frac`, `4`}, "125000\t-30000\n", exitOk, "12.5\t-3\n", ``)
+
+	testRun([]string{`transcode`}, ``, exitUsage, ``, "frac: invalid column \"\"; column numbers start with 1\n")
+	testRun([]string{`transcode`, `-columns`, `0`}, ``, exitUsage, ``, "frac: invalid column \"0\"; column numbers start with 1\n")
+	testRun([]string{`transcode`, `-columns`, `1`, `-radix`, `37`}, "1\n", exitInvalid, ``,
+		"frac: unable to transcode CSV: invalid column 0: unsupported radix 37\n")
+}
+
+func TestFiles(*testing.T) {
+	dir, err := os.MkdirTemp(``, `frac`)
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, `nums.txt`)
+	err = os.WriteFile(path, []byte("1\n2.x\n"), 0o600)
+	if err != nil {
+		panic(err)
+	}
+
+	testRun([]string{`parse`, path, `-`}, "3\n", exitInvalid, "100\n300\n",
+		path+`:2: syntax: unable to parse "2.x" as number (radix 10, fraction 2): found non-digit character 'x'`+"\n")
+
+	missing := filepath.Join(dir, `missing.txt`)
+	var stdout, stderr bytes.Buffer
+	code := run([]string{`parse`, missing}, strings.NewReader(``), &stdout, &stderr)
+	if code != exitInvalid || !strings.Contains(stderr.String(), `missing.txt`) {
+		panic(fmt.Errorf(`unexpected result for missing file: %v, %q`, code, stderr.String()))
+	}
+}
+
+func TestUsage(*testing.T) {
+	testRun(nil, ``, exitUsage, ``, usage)
+	testRun([]string{`help`}, ``, exitOk, usage, ``)
+	testRun([]string{`unknown`}, ``, exitUsage, ``, "frac: unknown command \"unknown\"\n"+usage)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{`parse`, `-unknown`}, strings.NewReader(``), &stdout, &stderr)
+	if code != exitUsage {
+		panic(fmt.Errorf(`expected exit code %v for unknown flag, got %v`, exitUsage, code))
+	}
+}
+
+func testRun(args []string, stdin string, expCode int, expOut string, expErr string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+
+	if stdout.String() != expOut {
+		panic(fmt.Errorf("expected %q to output:\n%v\ngot:\n%v", args, expOut, stdout.String()))
+	}
+	if stderr.String() != expErr {
+		panic(fmt.Errorf("expected %q to report:\n%v\ngot:\n%v", args, expErr, stderr.String()))
+	}
+	if code != expCode {
+		panic(fmt.Errorf(`expected %q to exit with %v, got %v`, args, expCode, code))
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mitranim/frac/fraccsv"
+)
+
+func (self *command) transcode(flags *flag.FlagSet, args []string, out io.Writer) int {
+	var trans fraccsv.Transcoder
+	var tmpl fraccsv.Column
+	var columns string
+	var tsv bool
+
+	flags.StringVar(&columns, `columns`, ``, `comma-separated columns to convert, starting with 1`)
+	flags.UintVar(&tmpl.Radix, `radix`, 10, `radix`)
+	flags.UintVar(&tmpl.From.Frac, `from-frac`, 2, `input fractional precision`)
+	flags.BoolVar(&tmpl.From.Scaled, `from-scaled`, false, `input values are scaled integers`)
+	flags.UintVar(&tmpl.To.Frac, `to-frac`, 2, `output fractional precision`)
+	flags.BoolVar(&tmpl.To.Scaled, `to-scaled`, false, `output values are scaled integers`)
+	flags.BoolVar(&tsv, `tsv`, false, `read and write tab-separated values rather than CSV`)
+	flags.BoolVar(&trans.Header, `header`, false, `copy the first row as-is`)
+	flags.BoolVar(&trans.Keep, `keep`, false, `copy rows with invalid values unchanged`)
+
+	if flags.Parse(args) != nil {
+		return exitUsage
+	}
+
+	for _, str := range strings.Split(columns, `,`) {
+		num, err := strconv.ParseUint(strings.TrimSpace(str), 10, 31)
+		if err != nil || num == 0 {
+			fmt.Fprintf(self.stderr, "frac: invalid column %q; column numbers start with 1\n", str)
+			return exitUsage
+		}
+		col := tmpl
+		col.Index = int(num) - 1
+		trans.Columns = append(trans.Columns, col)
+	}
+
+	writer := csv.NewWriter(out)
+	if tsv {
+		writer.Comma = '\t'
+	}
+	enc := json.NewEncoder(self.stderr)
+
+	code := self.each(flags.Args(), func(path string, file io.Reader) error {
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.ReuseRecord = true
+		if tsv {
+			reader.Comma = '\t'
+			reader.LazyQuotes = true
+		}
+
+		invalid, err := trans.Transcode(writer, reader, func(fail fraccsv.RowError) error {
+			if self.json {
+				return enc.Encode(result{
+					File:   path,
+					Line:   fail.Row,
+					Column: fail.Index + 1,
+					Input:  fail.Input,
+					Kind:   errKind(fail),
+					Error:  fail.Err.Error(),
+				})
+			}
+			_, err := fmt.Fprintf(
+				self.stderr, "%v:%v: %v: column %v: %v\n",
+				path, fail.Row, errKind(fail), fail.Index+1, fail.Err,
+			)
+			return err
+		})
+		if invalid > 0 {
+			self.invalid = true
+		}
+		return err
+	})
+
+	if code == exitOk && self.invalid {
+		return exitInvalid
+	}
+	return code
+}
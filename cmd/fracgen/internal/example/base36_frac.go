@@ -0,0 +1,197 @@
+// Code generated by "fracgen -type=Base36 -frac=20 -radix=36"; DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/mitranim/frac"
+)
+
+// Fractional precision and radix of `Base36`.
+const (
+	Base36Frac  = 20
+	Base36Radix = 36
+)
+
+// Parses a fractional string such as "-0.0000000000000000qglj" into `Base36` via `frac.Parse`.
+func ParseBase36(src string) (Base36, error) {
+	num, err := frac.Parse(src, Base36Frac, Base36Radix)
+	return Base36(num), err
+}
+
+// Like `ParseBase36` but panics on error. Intended for constants and tests.
+func MustParseBase36(src string) Base36 {
+	val, err := ParseBase36(src)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+/*
+Combines whole and fractional parts into `Base36` via `frac.FromParts`. The
+fractional part is in units of 36^-20, and the sign is given by `neg`.
+*/
+func Base36FromParts(whole int64, part uint64, neg bool) (Base36, error) {
+	num, err := frac.FromParts(whole, part, neg, Base36Frac, Base36Radix)
+	return Base36(num), err
+}
+
+// Converts a whole number into `Base36`, checking for overflow.
+func Base36FromWhole(whole int64) (Base36, error) {
+	return Base36FromParts(whole, 0, whole < 0)
+}
+
+// Splits the value into whole and fractional parts via `frac.Parts`.
+func (self Base36) Parts() (whole int64, part uint64, neg bool) {
+	whole, part, neg, _ = frac.Parts(int64(self), Base36Frac, Base36Radix)
+	return
+}
+
+// Implement `fmt.Stringer`. Formats the value via `frac.Format`.
+func (self Base36) String() string {
+	out, err := frac.Format(int64(self), Base36Frac, Base36Radix)
+	if err != nil {
+		return err.Error()
+	}
+	return out
+}
+
+/*
+Implement `fmt.Formatter` via `frac.FormatOpt.FromState`, supporting the flags
+of `frac.Fixed`. Accepts the verbs "%v", "%s"; other verbs are
+reported as bad verbs.
+*/
+func (self Base36) Format(out fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+	default:
+		fmt.Fprintf(out, `%%!%c(%T=%v)`, verb, self, self.String())
+		return
+	}
+
+	opt := frac.FormatOpt{Frac: Base36Frac, Radix: Base36Radix}.FromState(out)
+
+	var local [128]byte
+	buf, err := opt.Append(local[:0], int64(self))
+	if err != nil {
+		fmt.Fprintf(out, `%%!%c(%v)`, verb, err)
+		return
+	}
+	_, _ = out.Write(buf)
+}
+
+// Implement `encoding.TextMarshaler` via `frac.Append`.
+func (self Base36) MarshalText() ([]byte, error) {
+	return frac.Append(nil, int64(self), Base36Frac, Base36Radix)
+}
+
+// Implement `encoding.TextUnmarshaler` via `frac.Unmarshal`.
+func (self *Base36) UnmarshalText(src []byte) error {
+	num, err := frac.Unmarshal(src, Base36Frac, Base36Radix)
+	if err != nil {
+		return err
+	}
+	*self = Base36(num)
+	return nil
+}
+
+// Implement `json.Marshaler`. Encodes the value as a JSON string.
+func (self Base36) MarshalJSON() ([]byte, error) {
+	buf := append(make([]byte, 0, 24), '"')
+	buf, err := frac.Append(buf, int64(self), Base36Frac, Base36Radix)
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, '"'), nil
+}
+
+/*
+Implement `json.Unmarshaler`. Accepts JSON numbers and strings. Like the standard
+library, ignores null.
+*/
+func (self *Base36) UnmarshalJSON(src []byte) error {
+	if string(src) == `null` {
+		return nil
+	}
+	if len(src) >= 2 && src[0] == '"' && src[len(src)-1] == '"' {
+		src = src[1 : len(src)-1]
+	}
+	return self.UnmarshalText(src)
+}
+
+// Implement `driver.Valuer`. Stores the value as a fractional string.
+func (self Base36) Value() (driver.Value, error) {
+	return frac.Format(int64(self), Base36Frac, Base36Radix)
+}
+
+/*
+Implement `sql.Scanner`. Accepts strings and bytes, which are parsed via
+`frac.Parse`, and integers, which are treated as whole numbers. Rejects floats,
+which can't be converted without rounding, and nulls.
+*/
+func (self *Base36) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		val, err := ParseBase36(src)
+		if err != nil {
+			return err
+		}
+		*self = val
+		return nil
+
+	case []byte:
+		return self.UnmarshalText(src)
+
+	case int64:
+		val, err := Base36FromWhole(src)
+		if err != nil {
+			return err
+		}
+		*self = val
+		return nil
+
+	default:
+		return fmt.Errorf(`unable to scan %T into Base36`, src)
+	}
+}
+
+// Returns the sum, or an error wrapping `frac.ErrRange` on overflow.
+func (self Base36) Add(val Base36) (Base36, error) {
+	out := self + val
+	if (out > self) != (val > 0) {
+		return 0, fmt.Errorf(`unable to add %v to %v: %w`, val, self, frac.ErrRange)
+	}
+	return out, nil
+}
+
+// Returns the difference, or an error wrapping `frac.ErrRange` on overflow.
+func (self Base36) Sub(val Base36) (Base36, error) {
+	out := self - val
+	if (out < self) != (val > 0) {
+		return 0, fmt.Errorf(`unable to subtract %v from %v: %w`, val, self, frac.ErrRange)
+	}
+	return out, nil
+}
+
+/*
+Returns the value multiplied by a whole number, or an error wrapping
+`frac.ErrRange` on overflow.
+*/
+func (self Base36) Mul(val int64) (Base36, error) {
+	out := self * Base36(val)
+	if self != 0 && (int64(out/self) != val || (self == -1 && val == -1<<63) || (val == -1 && self == -1<<63)) {
+		return 0, fmt.Errorf(`unable to multiply %v by %v: %w`, self, val, frac.ErrRange)
+	}
+	return out, nil
+}
+
+// Returns the negated value, or an error wrapping `frac.ErrRange` on overflow.
+func (self Base36) Neg() (Base36, error) {
+	if self == -1<<63 {
+		return 0, fmt.Errorf(`unable to negate %v: %w`, self, frac.ErrRange)
+	}
+	return -self, nil
+}
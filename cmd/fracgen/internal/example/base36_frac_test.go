@@ -0,0 +1,176 @@
+// Code generated by "fracgen -type=Base36 -frac=20 -radix=36"; DO NOT EDIT.
+
+package example
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mitranim/frac"
+)
+
+var testBase36Cases = []struct {
+	Val  Base36
+	Text string
+}{
+	{0, `0`},
+	{1, `0.00000000000000000001`},
+	{-1, `-0.00000000000000000001`},
+	{35, `0.0000000000000000000z`},
+	{-35, `-0.0000000000000000000z`},
+	{9223372036854775807, `0.00000001y2p0ij32e8e7`},
+	{-1 << 63, `-0.00000001y2p0ij32e8e8`},
+}
+
+func TestBase36Text(t *testing.T) {
+	for _, tc := range testBase36Cases {
+		if act := tc.Val.String(); act != tc.Text {
+			t.Errorf(`expected %d to format as %q, got %q`, int64(tc.Val), tc.Text, act)
+		}
+
+		val, err := ParseBase36(tc.Text)
+		if err != nil || val != tc.Val {
+			t.Errorf(`expected %q to parse as %d, got %d (%v)`, tc.Text, int64(tc.Val), int64(val), err)
+		}
+
+		text, err := tc.Val.MarshalText()
+		if err != nil || string(text) != tc.Text {
+			t.Errorf(`expected %d to marshal as %q, got %q (%v)`, int64(tc.Val), tc.Text, text, err)
+		}
+
+		var out Base36
+		err = out.UnmarshalText([]byte(tc.Text))
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %q to unmarshal as %d, got %d (%v)`, tc.Text, int64(tc.Val), int64(out), err)
+		}
+	}
+}
+
+func TestBase36JSON(t *testing.T) {
+	for _, tc := range testBase36Cases {
+		src, err := json.Marshal(tc.Val)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out Base36
+		err = json.Unmarshal(src, &out)
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %s to decode as %d, got %d (%v)`, src, int64(tc.Val), int64(out), err)
+		}
+	}
+
+	var out Base36
+	err := json.Unmarshal([]byte(`null`), &out)
+	if err != nil || out != 0 {
+		t.Errorf(`expected null to be ignored, got %d (%v)`, int64(out), err)
+	}
+}
+
+func TestBase36SQL(t *testing.T) {
+	for _, tc := range testBase36Cases {
+		src, err := tc.Val.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out Base36
+		err = out.Scan(src)
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %v to scan as %d, got %d (%v)`, src, int64(tc.Val), int64(out), err)
+		}
+
+		out = 0
+		err = out.Scan([]byte(tc.Text))
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %q to scan as %d, got %d (%v)`, tc.Text, int64(tc.Val), int64(out), err)
+		}
+	}
+
+	var out Base36
+	for _, src := range []interface{}{nil, 1.5, true} {
+		if out.Scan(src) == nil {
+			t.Errorf(`expected scanning %#v to fail`, src)
+		}
+	}
+}
+
+func TestBase36Parts(t *testing.T) {
+	for _, tc := range testBase36Cases {
+		whole, part, neg := tc.Val.Parts()
+		val, err := Base36FromParts(whole, part, neg)
+		if err != nil || val != tc.Val {
+			t.Errorf(`expected parts of %d to combine into it, got %d (%v)`, int64(tc.Val), int64(val), err)
+		}
+	}
+}
+
+func TestBase36Arith(t *testing.T) {
+	const max, min = Base36(1<<63 - 1), Base36(-1 << 63)
+
+	for _, tc := range []struct {
+		Name string
+		Fun  func() (Base36, error)
+		Exp  Base36
+		Err  bool
+	}{
+		{`add`, func() (Base36, error) { return Base36(2).Add(3) }, 5, false},
+		{`add overflow`, func() (Base36, error) { return max.Add(1) }, 0, true},
+		{`add underflow`, func() (Base36, error) { return min.Add(-1) }, 0, true},
+		{`sub`, func() (Base36, error) { return Base36(2).Sub(3) }, -1, false},
+		{`sub overflow`, func() (Base36, error) { return max.Sub(-1) }, 0, true},
+		{`sub underflow`, func() (Base36, error) { return min.Sub(1) }, 0, true},
+		{`mul`, func() (Base36, error) { return Base36(-2).Mul(3) }, -6, false},
+		{`mul overflow`, func() (Base36, error) { return max.Mul(2) }, 0, true},
+		{`mul min`, func() (Base36, error) { return min.Mul(-1) }, 0, true},
+		{`mul by min`, func() (Base36, error) { return Base36(-1).Mul(-1 << 63) }, 0, true},
+		{`neg`, func() (Base36, error) { return max.Neg() }, -max, false},
+		{`neg min`, func() (Base36, error) { return min.Neg() }, 0, true},
+	} {
+		val, err := tc.Fun()
+		if tc.Err {
+			if !errors.Is(err, frac.ErrRange) {
+				t.Errorf(`%v: expected frac.ErrRange, got %d (%v)`, tc.Name, int64(val), err)
+			}
+			continue
+		}
+		if err != nil || val != tc.Exp {
+			t.Errorf(`%v: expected %d, got %d (%v)`, tc.Name, int64(tc.Exp), int64(val), err)
+		}
+	}
+}
+
+var testBase36Verbs = []struct {
+	Pattern string
+	Val     Base36
+	Text    string
+}{
+	{`%d`, 0, `%!d(example.Base36=0)`},
+	{`%.21f`, 0, `%!f(example.Base36=0)`},
+	{`%x`, 0, `%!x(example.Base36=0)`},
+	{`%+v`, 0, `+0`},
+	{`%d`, -1, `%!d(example.Base36=-0.00000000000000000001)`},
+	{`%.21f`, -1, `%!f(example.Base36=-0.00000000000000000001)`},
+	{`%x`, -1, `%!x(example.Base36=-0.00000000000000000001)`},
+	{`%+v`, -1, `-0.00000000000000000001`},
+	{`%d`, -1 << 63, `%!d(example.Base36=-0.00000001y2p0ij32e8e8)`},
+	{`%.21f`, -1 << 63, `%!f(example.Base36=-0.00000001y2p0ij32e8e8)`},
+	{`%x`, -1 << 63, `%!x(example.Base36=-0.00000001y2p0ij32e8e8)`},
+	{`%+v`, -1 << 63, `-0.00000001y2p0ij32e8e8`},
+}
+
+func TestBase36Format(t *testing.T) {
+	for _, tc := range testBase36Cases {
+		if act := fmt.Sprint(tc.Val); act != tc.Text {
+			t.Errorf(`expected %d to print as %q, got %q`, int64(tc.Val), tc.Text, act)
+		}
+	}
+
+	for _, tc := range testBase36Verbs {
+		if act := fmt.Sprintf(tc.Pattern, tc.Val); act != tc.Text {
+			t.Errorf(`expected %d to print via %q as %q, got %q`, int64(tc.Val), tc.Pattern, tc.Text, act)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Code generated by "fracgen -type=Cents -frac=2 -radix=10"; DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/mitranim/frac"
+)
+
+// Fractional precision and radix of `Cents`.
+const (
+	CentsFrac  = 2
+	CentsRadix = 10
+)
+
+// Parses a fractional string such as "-12345.67" into `Cents` via `frac.Parse`.
+func ParseCents(src string) (Cents, error) {
+	num, err := frac.Parse(src, CentsFrac, CentsRadix)
+	return Cents(num), err
+}
+
+// Like `ParseCents` but panics on error. Intended for constants and tests.
+func MustParseCents(src string) Cents {
+	val, err := ParseCents(src)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+/*
+Combines whole and fractional parts into `Cents` via `frac.FromParts`. The
+fractional part is in units of 10^-2, and the sign is given by `neg`.
+*/
+func CentsFromParts(whole int64, part uint64, neg bool) (Cents, error) {
+	num, err := frac.FromParts(whole, part, neg, CentsFrac, CentsRadix)
+	return Cents(num), err
+}
+
+// Converts a whole number into `Cents`, checking for overflow.
+func CentsFromWhole(whole int64) (Cents, error) {
+	return CentsFromParts(whole, 0, whole < 0)
+}
+
+// Splits the value into whole and fractional parts via `frac.Parts`.
+func (self Cents) Parts() (whole int64, part uint64, neg bool) {
+	whole, part, neg, _ = frac.Parts(int64(self), CentsFrac, CentsRadix)
+	return
+}
+
+// Implement `fmt.Stringer`. Formats the value via `frac.Format`.
+func (self Cents) String() string {
+	out, err := frac.Format(int64(self), CentsFrac, CentsRadix)
+	if err != nil {
+		return err.Error()
+	}
+	return out
+}
+
+/*
+Implement `fmt.Formatter` via `frac.FormatOpt.FromState`, supporting the flags
+of `frac.Fixed`. Accepts the verbs "%v", "%s", "%d", "%f"; other verbs are
+reported as bad verbs.
+*/
+func (self Cents) Format(out fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's', 'd', 'f':
+	default:
+		fmt.Fprintf(out, `%%!%c(%T=%v)`, verb, self, self.String())
+		return
+	}
+
+	opt := frac.FormatOpt{Frac: CentsFrac, Radix: CentsRadix}.FromState(out)
+
+	var local [128]byte
+	buf, err := opt.Append(local[:0], int64(self))
+	if err != nil {
+		fmt.Fprintf(out, `%%!%c(%v)`, verb, err)
+		return
+	}
+	_, _ = out.Write(buf)
+}
+
+// Implement `encoding.TextMarshaler` via `frac.Append`.
+func (self Cents) MarshalText() ([]byte, error) {
+	return frac.Append(nil, int64(self), CentsFrac, CentsRadix)
+}
+
+// Implement `encoding.TextUnmarshaler` via `frac.Unmarshal`.
+func (self *Cents) UnmarshalText(src []byte) error {
+	num, err := frac.Unmarshal(src, CentsFrac, CentsRadix)
+	if err != nil {
+		return err
+	}
+	*self = Cents(num)
+	return nil
+}
+
+// Implement `json.Marshaler`. Encodes the value as a JSON number without losing precision.
+func (self Cents) MarshalJSON() ([]byte, error) {
+	return self.MarshalText()
+}
+
+/*
+Implement `json.Unmarshaler`. Accepts JSON numbers and strings. Like the standard
+library, ignores null.
+*/
+func (self *Cents) UnmarshalJSON(src []byte) error {
+	if string(src) == `null` {
+		return nil
+	}
+	if len(src) >= 2 && src[0] == '"' && src[len(src)-1] == '"' {
+		src = src[1 : len(src)-1]
+	}
+	return self.UnmarshalText(src)
+}
+
+// Implement `driver.Valuer`. Stores the value as a fractional string.
+func (self Cents) Value() (driver.Value, error) {
+	return frac.Format(int64(self), CentsFrac, CentsRadix)
+}
+
+/*
+Implement `sql.Scanner`. Accepts strings and bytes, which are parsed via
+`frac.Parse`, and integers, which are treated as whole numbers. Rejects floats,
+which can't be converted without rounding, and nulls.
+*/
+func (self *Cents) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		val, err := ParseCents(src)
+		if err != nil {
+			return err
+		}
+		*self = val
+		return nil
+
+	case []byte:
+		return self.UnmarshalText(src)
+
+	case int64:
+		val, err := CentsFromWhole(src)
+		if err != nil {
+			return err
+		}
+		*self = val
+		return nil
+
+	default:
+		return fmt.Errorf(`unable to scan %T into Cents`, src)
+	}
+}
+
+// Returns the sum, or an error wrapping `frac.ErrRange` on overflow.
+func (self Cents) Add(val Cents) (Cents, error) {
+	out := self + val
+	if (out > self) != (val > 0) {
+		return 0, fmt.Errorf(`unable to add %v to %v: %w`, val, self, frac.ErrRange)
+	}
+	return out, nil
+}
+
+// Returns the difference, or an error wrapping `frac.ErrRange` on overflow.
+func (self Cents) Sub(val Cents) (Cents, error) {
+	out := self - val
+	if (out < self) != (val > 0) {
+		return 0, fmt.Errorf(`unable to subtract %v from %v: %w`, val, self, frac.ErrRange)
+	}
+	return out, nil
+}
+
+/*
+Returns the value multiplied by a whole number, or an error wrapping
+`frac.ErrRange` on overflow.
+*/
+func (self Cents) Mul(val int64) (Cents, error) {
+	out := self * Cents(val)
+	if self != 0 && (int64(out/self) != val || (self == -1 && val == -1<<63) || (val == -1 && self == -1<<63)) {
+		return 0, fmt.Errorf(`unable to multiply %v by %v: %w`, self, val, frac.ErrRange)
+	}
+	return out, nil
+}
+
+// Returns the negated value, or an error wrapping `frac.ErrRange` on overflow.
+func (self Cents) Neg() (Cents, error) {
+	if self == -1<<63 {
+		return 0, fmt.Errorf(`unable to negate %v: %w`, self, frac.ErrRange)
+	}
+	return -self, nil
+}
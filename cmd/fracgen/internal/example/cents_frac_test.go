@@ -0,0 +1,185 @@
+// Code generated by "fracgen -type=Cents -frac=2 -radix=10"; DO NOT EDIT.
+
+package example
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mitranim/frac"
+)
+
+var testCentsCases = []struct {
+	Val  Cents
+	Text string
+}{
+	{0, `0`},
+	{1, `0.01`},
+	{-1, `-0.01`},
+	{9, `0.09`},
+	{-9, `-0.09`},
+	{9223372036854775807, `92233720368547758.07`},
+	{-1 << 63, `-92233720368547758.08`},
+	{100, `1`},
+	{-100, `-1`},
+	{101, `1.01`},
+	{-101, `-1.01`},
+	{999, `9.99`},
+}
+
+func TestCentsText(t *testing.T) {
+	for _, tc := range testCentsCases {
+		if act := tc.Val.String(); act != tc.Text {
+			t.Errorf(`expected %d to format as %q, got %q`, int64(tc.Val), tc.Text, act)
+		}
+
+		val, err := ParseCents(tc.Text)
+		if err != nil || val != tc.Val {
+			t.Errorf(`expected %q to parse as %d, got %d (%v)`, tc.Text, int64(tc.Val), int64(val), err)
+		}
+
+		text, err := tc.Val.MarshalText()
+		if err != nil || string(text) != tc.Text {
+			t.Errorf(`expected %d to marshal as %q, got %q (%v)`, int64(tc.Val), tc.Text, text, err)
+		}
+
+		var out Cents
+		err = out.UnmarshalText([]byte(tc.Text))
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %q to unmarshal as %d, got %d (%v)`, tc.Text, int64(tc.Val), int64(out), err)
+		}
+	}
+}
+
+func TestCentsJSON(t *testing.T) {
+	for _, tc := range testCentsCases {
+		src, err := json.Marshal(tc.Val)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out Cents
+		err = json.Unmarshal(src, &out)
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %s to decode as %d, got %d (%v)`, src, int64(tc.Val), int64(out), err)
+		}
+	}
+
+	var out Cents
+	err := json.Unmarshal([]byte(`null`), &out)
+	if err != nil || out != 0 {
+		t.Errorf(`expected null to be ignored, got %d (%v)`, int64(out), err)
+	}
+}
+
+func TestCentsSQL(t *testing.T) {
+	for _, tc := range testCentsCases {
+		src, err := tc.Val.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out Cents
+		err = out.Scan(src)
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %v to scan as %d, got %d (%v)`, src, int64(tc.Val), int64(out), err)
+		}
+
+		out = 0
+		err = out.Scan([]byte(tc.Text))
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %q to scan as %d, got %d (%v)`, tc.Text, int64(tc.Val), int64(out), err)
+		}
+	}
+
+	var out Cents
+	for _, src := range []interface{}{nil, 1.5, true} {
+		if out.Scan(src) == nil {
+			t.Errorf(`expected scanning %#v to fail`, src)
+		}
+	}
+}
+
+func TestCentsParts(t *testing.T) {
+	for _, tc := range testCentsCases {
+		whole, part, neg := tc.Val.Parts()
+		val, err := CentsFromParts(whole, part, neg)
+		if err != nil || val != tc.Val {
+			t.Errorf(`expected parts of %d to combine into it, got %d (%v)`, int64(tc.Val), int64(val), err)
+		}
+	}
+}
+
+func TestCentsArith(t *testing.T) {
+	const max, min = Cents(1<<63 - 1), Cents(-1 << 63)
+
+	for _, tc := range []struct {
+		Name string
+		Fun  func() (Cents, error)
+		Exp  Cents
+		Err  bool
+	}{
+		{`add`, func() (Cents, error) { return Cents(2).Add(3) }, 5, false},
+		{`add overflow`, func() (Cents, error) { return max.Add(1) }, 0, true},
+		{`add underflow`, func() (Cents, error) { return min.Add(-1) }, 0, true},
+		{`sub`, func() (Cents, error) { return Cents(2).Sub(3) }, -1, false},
+		{`sub overflow`, func() (Cents, error) { return max.Sub(-1) }, 0, true},
+		{`sub underflow`, func() (Cents, error) { return min.Sub(1) }, 0, true},
+		{`mul`, func() (Cents, error) { return Cents(-2).Mul(3) }, -6, false},
+		{`mul overflow`, func() (Cents, error) { return max.Mul(2) }, 0, true},
+		{`mul min`, func() (Cents, error) { return min.Mul(-1) }, 0, true},
+		{`mul by min`, func() (Cents, error) { return Cents(-1).Mul(-1 << 63) }, 0, true},
+		{`neg`, func() (Cents, error) { return max.Neg() }, -max, false},
+		{`neg min`, func() (Cents, error) { return min.Neg() }, 0, true},
+	} {
+		val, err := tc.Fun()
+		if tc.Err {
+			if !errors.Is(err, frac.ErrRange) {
+				t.Errorf(`%v: expected frac.ErrRange, got %d (%v)`, tc.Name, int64(val), err)
+			}
+			continue
+		}
+		if err != nil || val != tc.Exp {
+			t.Errorf(`%v: expected %d, got %d (%v)`, tc.Name, int64(tc.Exp), int64(val), err)
+		}
+	}
+}
+
+var testCentsVerbs = []struct {
+	Pattern string
+	Val     Cents
+	Text    string
+}{
+	{`%d`, 0, `0`},
+	{`%.3f`, 0, `0.000`},
+	{`%x`, 0, `%!x(example.Cents=0)`},
+	{`%+v`, 0, `+0`},
+	{`%d`, 101, `1.01`},
+	{`%.3f`, 101, `1.010`},
+	{`%x`, 101, `%!x(example.Cents=1.01)`},
+	{`%+v`, 101, `+1.01`},
+	{`%d`, -101, `-1.01`},
+	{`%.3f`, -101, `-1.010`},
+	{`%x`, -101, `%!x(example.Cents=-1.01)`},
+	{`%+v`, -101, `-1.01`},
+	{`%d`, -1 << 63, `-92233720368547758.08`},
+	{`%.3f`, -1 << 63, `-92233720368547758.080`},
+	{`%x`, -1 << 63, `%!x(example.Cents=-92233720368547758.08)`},
+	{`%+v`, -1 << 63, `-92233720368547758.08`},
+}
+
+func TestCentsFormat(t *testing.T) {
+	for _, tc := range testCentsCases {
+		if act := fmt.Sprint(tc.Val); act != tc.Text {
+			t.Errorf(`expected %d to print as %q, got %q`, int64(tc.Val), tc.Text, act)
+		}
+	}
+
+	for _, tc := range testCentsVerbs {
+		if act := fmt.Sprintf(tc.Pattern, tc.Val); act != tc.Text {
+			t.Errorf(`expected %d to print via %q as %q, got %q`, int64(tc.Val), tc.Pattern, tc.Text, act)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// Types with generated methods, which verify the output of "fracgen".
+package example
+
+// Amount in cents.
+type Cents int64
+
+//go:generate go run github.com/mitranim/frac/cmd/fracgen -type=Cents -frac=2 -radix=10
+
+// Hexadecimal fixed-point number.
+type HexFixed int64
+
+//go:generate go run github.com/mitranim/frac/cmd/fracgen -type=HexFixed -frac=4 -radix=16
+
+// Base-36 number with a fractional precision beyond its whole range.
+type Base36 int64
+
+//go:generate go run github.com/mitranim/frac/cmd/fracgen -type=Base36 -frac=20 -radix=36
@@ -0,0 +1,198 @@
+// Code generated by "fracgen -type=HexFixed -frac=4 -radix=16"; DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/mitranim/frac"
+)
+
+// Fractional precision and radix of `HexFixed`.
+const (
+	HexFixedFrac  = 4
+	HexFixedRadix = 16
+)
+
+// Parses a fractional string such as "-12.d687" into `HexFixed` via `frac.Parse`.
+func ParseHexFixed(src string) (HexFixed, error) {
+	num, err := frac.Parse(src, HexFixedFrac, HexFixedRadix)
+	return HexFixed(num), err
+}
+
+// Like `ParseHexFixed` but panics on error. Intended for constants and tests.
+func MustParseHexFixed(src string) HexFixed {
+	val, err := ParseHexFixed(src)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+/*
+Combines whole and fractional parts into `HexFixed` via `frac.FromParts`. The
+fractional part is in units of 16^-4, and the sign is given by `neg`.
+*/
+func HexFixedFromParts(whole int64, part uint64, neg bool) (HexFixed, error) {
+	num, err := frac.FromParts(whole, part, neg, HexFixedFrac, HexFixedRadix)
+	return HexFixed(num), err
+}
+
+// Converts a whole number into `HexFixed`, checking for overflow.
+func HexFixedFromWhole(whole int64) (HexFixed, error) {
+	return HexFixedFromParts(whole, 0, whole < 0)
+}
+
+// Splits the value into whole and fractional parts via `frac.Parts`.
+func (self HexFixed) Parts() (whole int64, part uint64, neg bool) {
+	whole, part, neg, _ = frac.Parts(int64(self), HexFixedFrac, HexFixedRadix)
+	return
+}
+
+// Implement `fmt.Stringer`. Formats the value via `frac.Format`.
+func (self HexFixed) String() string {
+	out, err := frac.Format(int64(self), HexFixedFrac, HexFixedRadix)
+	if err != nil {
+		return err.Error()
+	}
+	return out
+}
+
+/*
+Implement `fmt.Formatter` via `frac.FormatOpt.FromState`, supporting the flags
+of `frac.Fixed`. Accepts the verbs "%v", "%s", "%x", "%X"; other verbs are
+reported as bad verbs.
+*/
+func (self HexFixed) Format(out fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's', 'x', 'X':
+	default:
+		fmt.Fprintf(out, `%%!%c(%T=%v)`, verb, self, self.String())
+		return
+	}
+
+	opt := frac.FormatOpt{Frac: HexFixedFrac, Radix: HexFixedRadix}.FromState(out)
+	opt.Upper = verb == 'X'
+
+	var local [128]byte
+	buf, err := opt.Append(local[:0], int64(self))
+	if err != nil {
+		fmt.Fprintf(out, `%%!%c(%v)`, verb, err)
+		return
+	}
+	_, _ = out.Write(buf)
+}
+
+// Implement `encoding.TextMarshaler` via `frac.Append`.
+func (self HexFixed) MarshalText() ([]byte, error) {
+	return frac.Append(nil, int64(self), HexFixedFrac, HexFixedRadix)
+}
+
+// Implement `encoding.TextUnmarshaler` via `frac.Unmarshal`.
+func (self *HexFixed) UnmarshalText(src []byte) error {
+	num, err := frac.Unmarshal(src, HexFixedFrac, HexFixedRadix)
+	if err != nil {
+		return err
+	}
+	*self = HexFixed(num)
+	return nil
+}
+
+// Implement `json.Marshaler`. Encodes the value as a JSON string.
+func (self HexFixed) MarshalJSON() ([]byte, error) {
+	buf := append(make([]byte, 0, 24), '"')
+	buf, err := frac.Append(buf, int64(self), HexFixedFrac, HexFixedRadix)
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, '"'), nil
+}
+
+/*
+Implement `json.Unmarshaler`. Accepts JSON numbers and strings. Like the standard
+library, ignores null.
+*/
+func (self *HexFixed) UnmarshalJSON(src []byte) error {
+	if string(src) == `null` {
+		return nil
+	}
+	if len(src) >= 2 && src[0] == '"' && src[len(src)-1] == '"' {
+		src = src[1 : len(src)-1]
+	}
+	return self.UnmarshalText(src)
+}
+
+// Implement `driver.Valuer`. Stores the value as a fractional string.
+func (self HexFixed) Value() (driver.Value, error) {
+	return frac.Format(int64(self), HexFixedFrac, HexFixedRadix)
+}
+
+/*
+Implement `sql.Scanner`. Accepts strings and bytes, which are parsed via
+`frac.Parse`, and integers, which are treated as whole numbers. Rejects floats,
+which can't be converted without rounding, and nulls.
+*/
+func (self *HexFixed) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		val, err := ParseHexFixed(src)
+		if err != nil {
+			return err
+		}
+		*self = val
+		return nil
+
+	case []byte:
+		return self.UnmarshalText(src)
+
+	case int64:
+		val, err := HexFixedFromWhole(src)
+		if err != nil {
+			return err
+		}
+		*self = val
+		return nil
+
+	default:
+		return fmt.Errorf(`unable to scan %T into HexFixed`, src)
+	}
+}
+
+// Returns the sum, or an error wrapping `frac.ErrRange` on overflow.
+func (self HexFixed) Add(val HexFixed) (HexFixed, error) {
+	out := self + val
+	if (out > self) != (val > 0) {
+		return 0, fmt.Errorf(`unable to add %v to %v: %w`, val, self, frac.ErrRange)
+	}
+	return out, nil
+}
+
+// Returns the difference, or an error wrapping `frac.ErrRange` on overflow.
+func (self HexFixed) Sub(val HexFixed) (HexFixed, error) {
+	out := self - val
+	if (out < self) != (val > 0) {
+		return 0, fmt.Errorf(`unable to subtract %v from %v: %w`, val, self, frac.ErrRange)
+	}
+	return out, nil
+}
+
+/*
+Returns the value multiplied by a whole number, or an error wrapping
+`frac.ErrRange` on overflow.
+*/
+func (self HexFixed) Mul(val int64) (HexFixed, error) {
+	out := self * HexFixed(val)
+	if self != 0 && (int64(out/self) != val || (self == -1 && val == -1<<63) || (val == -1 && self == -1<<63)) {
+		return 0, fmt.Errorf(`unable to multiply %v by %v: %w`, self, val, frac.ErrRange)
+	}
+	return out, nil
+}
+
+// Returns the negated value, or an error wrapping `frac.ErrRange` on overflow.
+func (self HexFixed) Neg() (HexFixed, error) {
+	if self == -1<<63 {
+		return 0, fmt.Errorf(`unable to negate %v: %w`, self, frac.ErrRange)
+	}
+	return -self, nil
+}
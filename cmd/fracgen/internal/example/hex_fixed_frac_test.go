@@ -0,0 +1,185 @@
+// Code generated by "fracgen -type=HexFixed -frac=4 -radix=16"; DO NOT EDIT.
+
+package example
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mitranim/frac"
+)
+
+var testHexFixedCases = []struct {
+	Val  HexFixed
+	Text string
+}{
+	{0, `0`},
+	{1, `0.0001`},
+	{-1, `-0.0001`},
+	{15, `0.000f`},
+	{-15, `-0.000f`},
+	{9223372036854775807, `7fffffffffff.ffff`},
+	{-1 << 63, `-800000000000`},
+	{65536, `1`},
+	{-65536, `-1`},
+	{65537, `1.0001`},
+	{-65537, `-1.0001`},
+	{1048575, `f.ffff`},
+}
+
+func TestHexFixedText(t *testing.T) {
+	for _, tc := range testHexFixedCases {
+		if act := tc.Val.String(); act != tc.Text {
+			t.Errorf(`expected %d to format as %q, got %q`, int64(tc.Val), tc.Text, act)
+		}
+
+		val, err := ParseHexFixed(tc.Text)
+		if err != nil || val != tc.Val {
+			t.Errorf(`expected %q to parse as %d, got %d (%v)`, tc.Text, int64(tc.Val), int64(val), err)
+		}
+
+		text, err := tc.Val.MarshalText()
+		if err != nil || string(text) != tc.Text {
+			t.Errorf(`expected %d to marshal as %q, got %q (%v)`, int64(tc.Val), tc.Text, text, err)
+		}
+
+		var out HexFixed
+		err = out.UnmarshalText([]byte(tc.Text))
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %q to unmarshal as %d, got %d (%v)`, tc.Text, int64(tc.Val), int64(out), err)
+		}
+	}
+}
+
+func TestHexFixedJSON(t *testing.T) {
+	for _, tc := range testHexFixedCases {
+		src, err := json.Marshal(tc.Val)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out HexFixed
+		err = json.Unmarshal(src, &out)
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %s to decode as %d, got %d (%v)`, src, int64(tc.Val), int64(out), err)
+		}
+	}
+
+	var out HexFixed
+	err := json.Unmarshal([]byte(`null`), &out)
+	if err != nil || out != 0 {
+		t.Errorf(`expected null to be ignored, got %d (%v)`, int64(out), err)
+	}
+}
+
+func TestHexFixedSQL(t *testing.T) {
+	for _, tc := range testHexFixedCases {
+		src, err := tc.Val.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out HexFixed
+		err = out.Scan(src)
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %v to scan as %d, got %d (%v)`, src, int64(tc.Val), int64(out), err)
+		}
+
+		out = 0
+		err = out.Scan([]byte(tc.Text))
+		if err != nil || out != tc.Val {
+			t.Errorf(`expected %q to scan as %d, got %d (%v)`, tc.Text, int64(tc.Val), int64(out), err)
+		}
+	}
+
+	var out HexFixed
+	for _, src := range []interface{}{nil, 1.5, true} {
+		if out.Scan(src) == nil {
+			t.Errorf(`expected scanning %#v to fail`, src)
+		}
+	}
+}
+
+func TestHexFixedParts(t *testing.T) {
+	for _, tc := range testHexFixedCases {
+		whole, part, neg := tc.Val.Parts()
+		val, err := HexFixedFromParts(whole, part, neg)
+		if err != nil || val != tc.Val {
+			t.Errorf(`expected parts of %d to combine into it, got %d (%v)`, int64(tc.Val), int64(val), err)
+		}
+	}
+}
+
+func TestHexFixedArith(t *testing.T) {
+	const max, min = HexFixed(1<<63 - 1), HexFixed(-1 << 63)
+
+	for _, tc := range []struct {
+		Name string
+		Fun  func() (HexFixed, error)
+		Exp  HexFixed
+		Err  bool
+	}{
+		{`add`, func() (HexFixed, error) { return HexFixed(2).Add(3) }, 5, false},
+		{`add overflow`, func() (HexFixed, error) { return max.Add(1) }, 0, true},
+		{`add underflow`, func() (HexFixed, error) { return min.Add(-1) }, 0, true},
+		{`sub`, func() (HexFixed, error) { return HexFixed(2).Sub(3) }, -1, false},
+		{`sub overflow`, func() (HexFixed, error) { return max.Sub(-1) }, 0, true},
+		{`sub underflow`, func() (HexFixed, error) { return min.Sub(1) }, 0, true},
+		{`mul`, func() (HexFixed, error) { return HexFixed(-2).Mul(3) }, -6, false},
+		{`mul overflow`, func() (HexFixed, error) { return max.Mul(2) }, 0, true},
+		{`mul min`, func() (HexFixed, error) { return min.Mul(-1) }, 0, true},
+		{`mul by min`, func() (HexFixed, error) { return HexFixed(-1).Mul(-1 << 63) }, 0, true},
+		{`neg`, func() (HexFixed, error) { return max.Neg() }, -max, false},
+		{`neg min`, func() (HexFixed, error) { return min.Neg() }, 0, true},
+	} {
+		val, err := tc.Fun()
+		if tc.Err {
+			if !errors.Is(err, frac.ErrRange) {
+				t.Errorf(`%v: expected frac.ErrRange, got %d (%v)`, tc.Name, int64(val), err)
+			}
+			continue
+		}
+		if err != nil || val != tc.Exp {
+			t.Errorf(`%v: expected %d, got %d (%v)`, tc.Name, int64(tc.Exp), int64(val), err)
+		}
+	}
+}
+
+var testHexFixedVerbs = []struct {
+	Pattern string
+	Val     HexFixed
+	Text    string
+}{
+	{`%d`, 0, `%!d(example.HexFixed=0)`},
+	{`%.5f`, 0, `%!f(example.HexFixed=0)`},
+	{`%x`, 0, `0`},
+	{`%+v`, 0, `+0`},
+	{`%d`, 65537, `%!d(example.HexFixed=1.0001)`},
+	{`%.5f`, 65537, `%!f(example.HexFixed=1.0001)`},
+	{`%x`, 65537, `1.0001`},
+	{`%+v`, 65537, `+1.0001`},
+	{`%d`, -65537, `%!d(example.HexFixed=-1.0001)`},
+	{`%.5f`, -65537, `%!f(example.HexFixed=-1.0001)`},
+	{`%x`, -65537, `-1.0001`},
+	{`%+v`, -65537, `-1.0001`},
+	{`%d`, -1 << 63, `%!d(example.HexFixed=-800000000000)`},
+	{`%.5f`, -1 << 63, `%!f(example.HexFixed=-800000000000)`},
+	{`%x`, -1 << 63, `-800000000000`},
+	{`%+v`, -1 << 63, `-800000000000`},
+}
+
+func TestHexFixedFormat(t *testing.T) {
+	for _, tc := range testHexFixedCases {
+		if act := fmt.Sprint(tc.Val); act != tc.Text {
+			t.Errorf(`expected %d to print as %q, got %q`, int64(tc.Val), tc.Text, act)
+		}
+	}
+
+	for _, tc := range testHexFixedVerbs {
+		if act := fmt.Sprintf(tc.Pattern, tc.Val); act != tc.Text {
+			t.Errorf(`expected %d to print via %q as %q, got %q`, int64(tc.Val), tc.Pattern, tc.Text, act)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+/*
+Generates methods for named fixed-point types backed by
+`github.com/mitranim/frac`. Intended for `go generate`:
+
+	type Cents int64
+
+	//go:generate fracgen -type=Cents -frac=2 -radix=10
+
+The type must be declared by the user, with the underlying type `int64`. For
+the type "Cents", writes "cents_frac.go" with:
+
+  - constants "CentsFrac" and "CentsRadix";
+  - constructors "ParseCents", "MustParseCents", "CentsFromParts" and
+    "CentsFromWhole", and the method "Parts";
+  - "String" and "Format", implementing `fmt.Stringer` and `fmt.Formatter`;
+  - text, JSON and SQL encoding and decoding;
+  - checked arithmetic: "Add", "Sub", "Mul" and "Neg".
+
+Also writes "cents_frac_test.go" with table-driven tests over boundary values,
+unless "-tests=false" is given.
+
+The package name is taken from "-package" or from the environment variable
+"GOPACKAGE", which is set by `go generate`.
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/mitranim/frac"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Getenv(`GOPACKAGE`), os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fracgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// Generation options, see the package docs.
+type config struct {
+	Type    string
+	Package string
+	Frac    uint
+	Radix   uint
+	Output  string
+	Tests   bool
+	Dir     string
+}
+
+func run(args []string, pkg string, stderr io.Writer) error {
+	conf := config{Package: pkg}
+
+	flags := flag.NewFlagSet(`fracgen`, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&conf.Type, `type`, ``, `name of the type; required`)
+	flags.StringVar(&conf.Package, `package`, conf.Package, `package name; defaults to $GOPACKAGE`)
+	flags.UintVar(&conf.Frac, `frac`, 2, `fractional precision`)
+	flags.UintVar(&conf.Radix, `radix`, 10, `radix`)
+	flags.StringVar(&conf.Output, `output`, ``, `output file; defaults to <type>_frac.go`)
+	flags.BoolVar(&conf.Tests, `tests`, true, `also generate <output>_test.go`)
+	flags.StringVar(&conf.Dir, `dir`, `.`, `output directory`)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf(`unexpected arguments %q`, flags.Args())
+	}
+
+	files, err := generate(conf)
+	if err != nil {
+		return err
+	}
+
+	for name, src := range files {
+		err = os.WriteFile(filepath.Join(conf.Dir, name), src, 0o644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns generated sources by file name.
+func generate(conf config) (map[string][]byte, error) {
+	err := conf.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := conf.data()
+	if err != nil {
+		return nil, err
+	}
+
+	output := conf.Output
+	if output == `` {
+		output = snakeCase(conf.Type) + `_frac.go`
+	}
+
+	out := map[string][]byte{}
+	out[output], err = render(typeTmpl, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.Tests {
+		out[strings.TrimSuffix(output, `.go`)+`_test.go`], err = render(testTmpl, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (self config) validate() error {
+	if !token.IsIdentifier(self.Type) {
+		return fmt.Errorf(`invalid type name %q`, self.Type)
+	}
+	if !token.IsIdentifier(self.Package) {
+		return fmt.Errorf(`invalid package name %q; set -package or $GOPACKAGE`, self.Package)
+	}
+	return frac.FormatOpt{Frac: self.Frac, Radix: self.Radix}.Validate()
+}
+
+// Data for the templates.
+type tmplData struct {
+	Args      string
+	Package   string
+	Type      string
+	Frac      uint
+	Radix     uint
+	Example   string
+	Verbs     []string
+	Upper     bool
+	Quote     bool
+	Cases     []tmplCase
+	VerbCases []tmplVerbCase
+}
+
+type tmplCase struct {
+	Num  string
+	Text string
+}
+
+type tmplVerbCase struct {
+	Pattern string
+	Num     string
+	Text    string
+}
+
+func (self config) data() (tmplData, error) {
+	out := tmplData{
+		Args:    fmt.Sprintf(`-type=%v -frac=%v -radix=%v`, self.Type, self.Frac, self.Radix),
+		Package: self.Package,
+		Type:    self.Type,
+		Frac:    self.Frac,
+		Radix:   self.Radix,
+		Quote:   self.Radix != 10,
+	}
+
+	// Verbs other than "%v" and "%s" imply a radix; see `frac.Fixed`.
+	switch self.Radix {
+	case 2:
+		out.Verbs = []string{`b`}
+	case 8:
+		out.Verbs = []string{`o`}
+	case 10:
+		out.Verbs = []string{`d`, `f`}
+	case 16:
+		out.Verbs = []string{`x`, `X`}
+		out.Upper = true
+	}
+
+	var err error
+	out.Example, err = frac.Format(-1234567, self.Frac, self.Radix)
+	if err != nil {
+		return out, err
+	}
+
+	for _, num := range caseNums(self.Frac, self.Radix) {
+		text, err := frac.Format(num, self.Frac, self.Radix)
+		if err != nil {
+			return out, err
+		}
+		out.Cases = append(out.Cases, tmplCase{Num: numLiteral(num), Text: text})
+	}
+
+	for _, num := range verbNums(self.Frac, self.Radix) {
+		for _, verb := range []struct {
+			pattern string
+			char    string
+			opt     frac.FormatOpt
+		}{
+			{`%d`, `d`, frac.FormatOpt{}},
+			{fmt.Sprintf(`%%.%vf`, self.Frac+1), `f`, frac.FormatOpt{MinFrac: self.Frac + 1}},
+			{`%x`, `x`, frac.FormatOpt{}},
+			{`%+v`, `v`, frac.FormatOpt{Sign: frac.SignPlus}},
+		} {
+			text, err := out.verbText(verb.char, verb.opt, num)
+			if err != nil {
+				return out, err
+			}
+			out.VerbCases = append(out.VerbCases, tmplVerbCase{
+				Pattern: verb.pattern,
+				Num:     numLiteral(num),
+				Text:    text,
+			})
+		}
+	}
+	return out, nil
+}
+
+/*
+Expected output of the generated `Format` method for the given verb: either
+formatted with the options, or reported as a bad verb.
+*/
+func (self tmplData) verbText(verb string, opt frac.FormatOpt, num int64) (string, error) {
+	opt.Frac, opt.Radix = self.Frac, self.Radix
+
+	if verb != `v` && !hasString(self.Verbs, verb) {
+		text, err := frac.Format(num, self.Frac, self.Radix)
+		return fmt.Sprintf(`%%!%v(%v.%v=%v)`, verb, self.Package, self.Type, text), err
+	}
+	return opt.Format(num)
+}
+
+func hasString(vals []string, val string) bool {
+	for _, elem := range vals {
+		if elem == val {
+			return true
+		}
+	}
+	return false
+}
+
+// Values for generated tests of formatting verbs.
+func verbNums(fracVal uint, radix uint) []int64 {
+	pow, err := frac.Pow(radix, fracVal)
+	if err != nil {
+		return []int64{0, -1, math.MinInt64}
+	}
+	return []int64{0, pow + 1, -pow - 1, math.MinInt64}
+}
+
+// Boundary values for generated tests, without duplicates.
+func caseNums(fracVal uint, radix uint) (out []int64) {
+	nums := []int64{0, 1, -1, int64(radix) - 1, -int64(radix) + 1, math.MaxInt64, math.MinInt64}
+
+	pow, err := frac.Pow(radix, fracVal)
+	if err == nil {
+		nums = append(nums, pow, -pow, pow+1, -pow-1)
+		if pow <= math.MaxInt64/int64(radix) {
+			nums = append(nums, pow*int64(radix)-1)
+		}
+	}
+
+	seen := map[int64]bool{}
+	for _, num := range nums {
+		if !seen[num] {
+			seen[num] = true
+			out = append(out, num)
+		}
+	}
+	return
+}
+
+// `math.MinInt64` can't be written as a negated literal that fits `int64`.
+func numLiteral(num int64) string {
+	if num == math.MinInt64 {
+		return `-1 << 63`
+	}
+	return fmt.Sprint(num)
+}
+
+func render(tmpl *template.Template, data tmplData) ([]byte, error) {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf(`internal error: generated invalid code: %w`, err)
+	}
+	return out, nil
+}
+
+// Converts "MoneyUSD" into "money_usd".
+func snakeCase(src string) string {
+	var buf strings.Builder
+	runes := []rune(src)
+
+	for ind, char := range runes {
+		if unicode.IsUpper(char) && ind > 0 &&
+			(unicode.IsLower(runes[ind-1]) || (ind+1 < len(runes) && unicode.IsLower(runes[ind+1]))) {
+			buf.WriteByte('_')
+		}
+		buf.WriteRune(unicode.ToLower(char))
+	}
+	return buf.String()
+}
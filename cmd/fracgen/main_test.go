@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The example package is generated via `go generate` and compiled with its
+// generated tests. This verifies that it's up to date.
+func TestGenerateExample(*testing.T) {
+	for _, conf := range []config{
+		{Type: `Cents`, Frac: 2, Radix: 10},
+		{Type: `HexFixed`, Frac: 4, Radix: 16},
+		{Type: `Base36`, Frac: 20, Radix: 36},
+	} {
+		conf.Package = `example`
+		conf.Tests = true
+
+		files, err := generate(conf)
+		if err != nil {
+			panic(err)
+		}
+		if len(files) != 2 {
+			panic(fmt.Errorf(`expected 2 files for %v, got %v`, conf.Type, len(files)))
+		}
+
+		for name, act := range files {
+			exp, err := os.ReadFile(filepath.Join(`internal`, `example`, name))
+			if err != nil {
+				panic(err)
+			}
+			if !bytes.Equal(act, exp) {
+				panic(fmt.Errorf(`generated %v differs from the example; run "go generate ./..."`, name))
+			}
+		}
+	}
+}
+
+func TestRun(*testing.T) {
+	dir, err := os.MkdirTemp(``, `fracgen`)
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = run([]string{`-type=Price`, `-frac=4`, `-output=price.go`, `-tests=false`, `-dir`, dir}, `shop`, io.Discard)
+	if err != nil {
+		panic(err)
+	}
+
+	src, err := os.ReadFile(filepath.Join(dir, `price.go`))
+	if err != nil {
+		panic(err)
+	}
+	for _, exp := range []string{
+		`// Code generated by "fracgen -type=Price -frac=4 -radix=10"; DO NOT EDIT.`,
+		"package shop\n",
+		"PriceFrac  = 4\n",
+		`func ParsePrice(src string) (Price, error) {`,
+	} {
+		if !strings.Contains(string(src), exp) {
+			panic(fmt.Errorf(`expected generated code to contain %q`, exp))
+		}
+	}
+
+	_, err = os.Stat(filepath.Join(dir, `price_test.go`))
+	if !os.IsNotExist(err) {
+		panic(fmt.Errorf(`expected no test file, got %v`, err))
+	}
+
+	testRunErr([]string{}, `pkg`, `invalid type name ""`)
+	testRunErr([]string{`-type=1x`}, `pkg`, `invalid type name "1x"`)
+	testRunErr([]string{`-type=Cents`}, ``, `invalid package name ""; set -package or $GOPACKAGE`)
+	testRunErr([]string{`-type=Cents`, `-radix=37`}, `pkg`, `unsupported radix 37`)
+	testRunErr([]string{`-type=Cents`, `-frac=65`}, `pkg`, `fractional precision 65 exceeds limit 64`)
+	testRunErr([]string{`-type=Cents`, `extra`}, `pkg`, `unexpected arguments ["extra"]`)
+}
+
+func TestSnakeCase(*testing.T) {
+	for src, exp := range map[string]string{
+		`Cents`:    `cents`,
+		`HexFixed`: `hex_fixed`,
+		`MoneyUSD`: `money_usd`,
+		`USDMoney`: `usd_money`,
+		`cents`:    `cents`,
+		`Base36`:   `base36`,
+	} {
+		if act := snakeCase(src); act != exp {
+			panic(fmt.Errorf(`expected %q to convert to %q, got %q`, src, exp, act))
+		}
+	}
+}
+
+func testRunErr(args []string, pkg string, msg string) {
+	err := run(args, pkg, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected %q to fail with %q, got %v`, args, msg, err))
+	}
+}
@@ -0,0 +1,374 @@
+package main
+
+import "text/template"
+
+var typeTmpl = template.Must(template.New(`type`).Parse(`// Code generated by "fracgen {{.Args}}"; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/mitranim/frac"
+)
+
+// Fractional precision and radix of ` + "`{{.Type}}`" + `.
+const (
+	{{.Type}}Frac  = {{.Frac}}
+	{{.Type}}Radix = {{.Radix}}
+)
+
+// Parses a fractional string such as "{{.Example}}" into ` + "`{{.Type}}`" + ` via ` + "`frac.Parse`" + `.
+func Parse{{.Type}}(src string) ({{.Type}}, error) {
+	num, err := frac.Parse(src, {{.Type}}Frac, {{.Type}}Radix)
+	return {{.Type}}(num), err
+}
+
+// Like ` + "`Parse{{.Type}}`" + ` but panics on error. Intended for constants and tests.
+func MustParse{{.Type}}(src string) {{.Type}} {
+	val, err := Parse{{.Type}}(src)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+/*
+Combines whole and fractional parts into ` + "`{{.Type}}`" + ` via ` + "`frac.FromParts`" + `. The
+fractional part is in units of {{.Radix}}^-{{.Frac}}, and the sign is given by ` + "`neg`" + `.
+*/
+func {{.Type}}FromParts(whole int64, part uint64, neg bool) ({{.Type}}, error) {
+	num, err := frac.FromParts(whole, part, neg, {{.Type}}Frac, {{.Type}}Radix)
+	return {{.Type}}(num), err
+}
+
+// Converts a whole number into ` + "`{{.Type}}`" + `, checking for overflow.
+func {{.Type}}FromWhole(whole int64) ({{.Type}}, error) {
+	return {{.Type}}FromParts(whole, 0, whole < 0)
+}
+
+// Splits the value into whole and fractional parts via ` + "`frac.Parts`" + `.
+func (self {{.Type}}) Parts() (whole int64, part uint64, neg bool) {
+	whole, part, neg, _ = frac.Parts(int64(self), {{.Type}}Frac, {{.Type}}Radix)
+	return
+}
+
+// Implement ` + "`fmt.Stringer`" + `. Formats the value via ` + "`frac.Format`" + `.
+func (self {{.Type}}) String() string {
+	out, err := frac.Format(int64(self), {{.Type}}Frac, {{.Type}}Radix)
+	if err != nil {
+		return err.Error()
+	}
+	return out
+}
+
+/*
+Implement ` + "`fmt.Formatter`" + ` via ` + "`frac.FormatOpt.FromState`" + `, supporting the flags
+of ` + "`frac.Fixed`" + `. Accepts the verbs "%v", "%s"{{range .Verbs}}, "%{{.}}"{{end}}; other verbs are
+reported as bad verbs.
+*/
+func (self {{.Type}}) Format(out fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's'{{range .Verbs}}, '{{.}}'{{end}}:
+	default:
+		fmt.Fprintf(out, ` + "`%%!%c(%T=%v)`" + `, verb, self, self.String())
+		return
+	}
+
+	opt := frac.FormatOpt{Frac: {{.Type}}Frac, Radix: {{.Type}}Radix}.FromState(out)
+{{- if .Upper}}
+	opt.Upper = verb == 'X'
+{{- end}}
+
+	var local [128]byte
+	buf, err := opt.Append(local[:0], int64(self))
+	if err != nil {
+		fmt.Fprintf(out, ` + "`%%!%c(%v)`" + `, verb, err)
+		return
+	}
+	_, _ = out.Write(buf)
+}
+
+// Implement ` + "`encoding.TextMarshaler`" + ` via ` + "`frac.Append`" + `.
+func (self {{.Type}}) MarshalText() ([]byte, error) {
+	return frac.Append(nil, int64(self), {{.Type}}Frac, {{.Type}}Radix)
+}
+
+// Implement ` + "`encoding.TextUnmarshaler`" + ` via ` + "`frac.Unmarshal`" + `.
+func (self *{{.Type}}) UnmarshalText(src []byte) error {
+	num, err := frac.Unmarshal(src, {{.Type}}Frac, {{.Type}}Radix)
+	if err != nil {
+		return err
+	}
+	*self = {{.Type}}(num)
+	return nil
+}
+
+// Implement ` + "`json.Marshaler`" + `.{{if .Quote}} Encodes the value as a JSON string.{{else}} Encodes the value as a JSON number without losing precision.{{end}}
+func (self {{.Type}}) MarshalJSON() ([]byte, error) {
+{{- if .Quote}}
+	buf := append(make([]byte, 0, 24), '"')
+	buf, err := frac.Append(buf, int64(self), {{.Type}}Frac, {{.Type}}Radix)
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, '"'), nil
+{{- else}}
+	return self.MarshalText()
+{{- end}}
+}
+
+/*
+Implement ` + "`json.Unmarshaler`" + `. Accepts JSON numbers and strings. Like the standard
+library, ignores null.
+*/
+func (self *{{.Type}}) UnmarshalJSON(src []byte) error {
+	if string(src) == ` + "`null`" + ` {
+		return nil
+	}
+	if len(src) >= 2 && src[0] == '"' && src[len(src)-1] == '"' {
+		src = src[1 : len(src)-1]
+	}
+	return self.UnmarshalText(src)
+}
+
+// Implement ` + "`driver.Valuer`" + `. Stores the value as a fractional string.
+func (self {{.Type}}) Value() (driver.Value, error) {
+	return frac.Format(int64(self), {{.Type}}Frac, {{.Type}}Radix)
+}
+
+/*
+Implement ` + "`sql.Scanner`" + `. Accepts strings and bytes, which are parsed via
+` + "`frac.Parse`" + `, and integers, which are treated as whole numbers. Rejects floats,
+which can't be converted without rounding, and nulls.
+*/
+func (self *{{.Type}}) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		val, err := Parse{{.Type}}(src)
+		if err != nil {
+			return err
+		}
+		*self = val
+		return nil
+
+	case []byte:
+		return self.UnmarshalText(src)
+
+	case int64:
+		val, err := {{.Type}}FromWhole(src)
+		if err != nil {
+			return err
+		}
+		*self = val
+		return nil
+
+	default:
+		return fmt.Errorf(` + "`unable to scan %T into {{.Type}}`" + `, src)
+	}
+}
+
+// Returns the sum, or an error wrapping ` + "`frac.ErrRange`" + ` on overflow.
+func (self {{.Type}}) Add(val {{.Type}}) ({{.Type}}, error) {
+	out := self + val
+	if (out > self) != (val > 0) {
+		return 0, fmt.Errorf(` + "`unable to add %v to %v: %w`" + `, val, self, frac.ErrRange)
+	}
+	return out, nil
+}
+
+// Returns the difference, or an error wrapping ` + "`frac.ErrRange`" + ` on overflow.
+func (self {{.Type}}) Sub(val {{.Type}}) ({{.Type}}, error) {
+	out := self - val
+	if (out < self) != (val > 0) {
+		return 0, fmt.Errorf(` + "`unable to subtract %v from %v: %w`" + `, val, self, frac.ErrRange)
+	}
+	return out, nil
+}
+
+/*
+Returns the value multiplied by a whole number, or an error wrapping
+` + "`frac.ErrRange`" + ` on overflow.
+*/
+func (self {{.Type}}) Mul(val int64) ({{.Type}}, error) {
+	out := self * {{.Type}}(val)
+	if self != 0 && (int64(out/self) != val || (self == -1 && val == -1<<63) || (val == -1 && self == -1<<63)) {
+		return 0, fmt.Errorf(` + "`unable to multiply %v by %v: %w`" + `, self, val, frac.ErrRange)
+	}
+	return out, nil
+}
+
+// Returns the negated value, or an error wrapping ` + "`frac.ErrRange`" + ` on overflow.
+func (self {{.Type}}) Neg() ({{.Type}}, error) {
+	if self == -1<<63 {
+		return 0, fmt.Errorf(` + "`unable to negate %v: %w`" + `, self, frac.ErrRange)
+	}
+	return -self, nil
+}
+`))
+
+var testTmpl = template.Must(template.New(`test`).Parse(`// Code generated by "fracgen {{.Args}}"; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mitranim/frac"
+)
+
+var test{{.Type}}Cases = []struct {
+	Val  {{.Type}}
+	Text string
+}{
+{{- range .Cases}}
+	{ {{- .Num}}, ` + "`{{.Text}}`" + `},
+{{- end}}
+}
+
+func Test{{.Type}}Text(t *testing.T) {
+	for _, tc := range test{{.Type}}Cases {
+		if act := tc.Val.String(); act != tc.Text {
+			t.Errorf(` + "`expected %d to format as %q, got %q`" + `, int64(tc.Val), tc.Text, act)
+		}
+
+		val, err := Parse{{.Type}}(tc.Text)
+		if err != nil || val != tc.Val {
+			t.Errorf(` + "`expected %q to parse as %d, got %d (%v)`" + `, tc.Text, int64(tc.Val), int64(val), err)
+		}
+
+		text, err := tc.Val.MarshalText()
+		if err != nil || string(text) != tc.Text {
+			t.Errorf(` + "`expected %d to marshal as %q, got %q (%v)`" + `, int64(tc.Val), tc.Text, text, err)
+		}
+
+		var out {{.Type}}
+		err = out.UnmarshalText([]byte(tc.Text))
+		if err != nil || out != tc.Val {
+			t.Errorf(` + "`expected %q to unmarshal as %d, got %d (%v)`" + `, tc.Text, int64(tc.Val), int64(out), err)
+		}
+	}
+}
+
+func Test{{.Type}}JSON(t *testing.T) {
+	for _, tc := range test{{.Type}}Cases {
+		src, err := json.Marshal(tc.Val)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out {{.Type}}
+		err = json.Unmarshal(src, &out)
+		if err != nil || out != tc.Val {
+			t.Errorf(` + "`expected %s to decode as %d, got %d (%v)`" + `, src, int64(tc.Val), int64(out), err)
+		}
+	}
+
+	var out {{.Type}}
+	err := json.Unmarshal([]byte(` + "`null`" + `), &out)
+	if err != nil || out != 0 {
+		t.Errorf(` + "`expected null to be ignored, got %d (%v)`" + `, int64(out), err)
+	}
+}
+
+func Test{{.Type}}SQL(t *testing.T) {
+	for _, tc := range test{{.Type}}Cases {
+		src, err := tc.Val.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out {{.Type}}
+		err = out.Scan(src)
+		if err != nil || out != tc.Val {
+			t.Errorf(` + "`expected %v to scan as %d, got %d (%v)`" + `, src, int64(tc.Val), int64(out), err)
+		}
+
+		out = 0
+		err = out.Scan([]byte(tc.Text))
+		if err != nil || out != tc.Val {
+			t.Errorf(` + "`expected %q to scan as %d, got %d (%v)`" + `, tc.Text, int64(tc.Val), int64(out), err)
+		}
+	}
+
+	var out {{.Type}}
+	for _, src := range []interface{}{nil, 1.5, true} {
+		if out.Scan(src) == nil {
+			t.Errorf(` + "`expected scanning %#v to fail`" + `, src)
+		}
+	}
+}
+
+func Test{{.Type}}Parts(t *testing.T) {
+	for _, tc := range test{{.Type}}Cases {
+		whole, part, neg := tc.Val.Parts()
+		val, err := {{.Type}}FromParts(whole, part, neg)
+		if err != nil || val != tc.Val {
+			t.Errorf(` + "`expected parts of %d to combine into it, got %d (%v)`" + `, int64(tc.Val), int64(val), err)
+		}
+	}
+}
+
+func Test{{.Type}}Arith(t *testing.T) {
+	const max, min = {{.Type}}(1<<63 - 1), {{.Type}}(-1 << 63)
+
+	for _, tc := range []struct {
+		Name string
+		Fun  func() ({{.Type}}, error)
+		Exp  {{.Type}}
+		Err  bool
+	}{
+		{` + "`add`" + `, func() ({{.Type}}, error) { return {{.Type}}(2).Add(3) }, 5, false},
+		{` + "`add overflow`" + `, func() ({{.Type}}, error) { return max.Add(1) }, 0, true},
+		{` + "`add underflow`" + `, func() ({{.Type}}, error) { return min.Add(-1) }, 0, true},
+		{` + "`sub`" + `, func() ({{.Type}}, error) { return {{.Type}}(2).Sub(3) }, -1, false},
+		{` + "`sub overflow`" + `, func() ({{.Type}}, error) { return max.Sub(-1) }, 0, true},
+		{` + "`sub underflow`" + `, func() ({{.Type}}, error) { return min.Sub(1) }, 0, true},
+		{` + "`mul`" + `, func() ({{.Type}}, error) { return {{.Type}}(-2).Mul(3) }, -6, false},
+		{` + "`mul overflow`" + `, func() ({{.Type}}, error) { return max.Mul(2) }, 0, true},
+		{` + "`mul min`" + `, func() ({{.Type}}, error) { return min.Mul(-1) }, 0, true},
+		{` + "`mul by min`" + `, func() ({{.Type}}, error) { return {{.Type}}(-1).Mul(-1 << 63) }, 0, true},
+		{` + "`neg`" + `, func() ({{.Type}}, error) { return max.Neg() }, -max, false},
+		{` + "`neg min`" + `, func() ({{.Type}}, error) { return min.Neg() }, 0, true},
+	} {
+		val, err := tc.Fun()
+		if tc.Err {
+			if !errors.Is(err, frac.ErrRange) {
+				t.Errorf(` + "`%v: expected frac.ErrRange, got %d (%v)`" + `, tc.Name, int64(val), err)
+			}
+			continue
+		}
+		if err != nil || val != tc.Exp {
+			t.Errorf(` + "`%v: expected %d, got %d (%v)`" + `, tc.Name, int64(tc.Exp), int64(val), err)
+		}
+	}
+}
+
+var test{{.Type}}Verbs = []struct {
+	Pattern string
+	Val     {{.Type}}
+	Text    string
+}{
+{{- range .VerbCases}}
+	{` + "`{{.Pattern}}`" + `, {{.Num}}, ` + "`{{.Text}}`" + `},
+{{- end}}
+}
+
+func Test{{.Type}}Format(t *testing.T) {
+	for _, tc := range test{{.Type}}Cases {
+		if act := fmt.Sprint(tc.Val); act != tc.Text {
+			t.Errorf(` + "`expected %d to print as %q, got %q`" + `, int64(tc.Val), tc.Text, act)
+		}
+	}
+
+	for _, tc := range test{{.Type}}Verbs {
+		if act := fmt.Sprintf(tc.Pattern, tc.Val); act != tc.Text {
+			t.Errorf(` + "`expected %d to print via %q as %q, got %q`" + `, int64(tc.Val), tc.Pattern, tc.Text, act)
+		}
+	}
+}
+`))
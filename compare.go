@@ -0,0 +1,150 @@
+package frac
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+/*
+Compares two scaled integers at possibly different fractional precisions of the
+same radix, returning -1 when `a < b`, 0 when `a == b`, and 1 when `a > b`. For
+example, in radix 10, 12_3400 at frac 4 equals 12_34 at frac 2, and 12_3401 at
+frac 4 is greater. The comparison is exact: the number with the lower precision
+is widened to 128 bits internally, so it never overflows.
+
+Supports radixes up to 64, matching `Alphabet`. Panics on an unsupported radix,
+which is a programmer error rather than a data error.
+*/
+func Compare(a int64, fa uint, b int64, fb uint, radix uint) int {
+	if !validRadixAny(radix) {
+		panic(fmt.Errorf(`unable to compare %v and %v: unsupported radix %v`, a, b, radix))
+	}
+
+	signA, signB := sign(a), sign(b)
+	if signA != signB {
+		return cmpInt(signA, signB)
+	}
+	if signA == 0 {
+		return 0
+	}
+
+	var out int
+	if fa <= fb {
+		out = cmpScaled(magnitude(a), fb-fa, magnitude(b), radix)
+	} else {
+		out = -cmpScaled(magnitude(b), fa-fb, magnitude(a), radix)
+	}
+	return out * signA
+}
+
+// Same as `Compare(a, fa, b, fb, radix) == 0`.
+func Equal(a int64, fa uint, b int64, fb uint, radix uint) bool {
+	return Compare(a, fa, b, fb, radix) == 0
+}
+
+/*
+Returns the smaller of two scaled integers with their fractional precisions,
+compared via `Compare`. When they're equal, returns the first.
+*/
+func Min(a int64, fa uint, b int64, fb uint, radix uint) (int64, uint) {
+	if Compare(a, fa, b, fb, radix) <= 0 {
+		return a, fa
+	}
+	return b, fb
+}
+
+/*
+Returns the larger of two scaled integers with their fractional precisions,
+compared via `Compare`. When they're equal, returns the first.
+*/
+func Max(a int64, fa uint, b int64, fb uint, radix uint) (int64, uint) {
+	if Compare(a, fa, b, fb, radix) >= 0 {
+		return a, fa
+	}
+	return b, fb
+}
+
+/*
+Implements `sort.Interface` for `Fixed` values at possibly different
+precisions, ordering them by value via `Compare` in the given radix. See
+`SortFixed` for a shortcut.
+*/
+type FixedSlice struct {
+	Slice []Fixed
+	Radix uint
+}
+
+// Implement `sort.Interface`.
+func (self FixedSlice) Len() int { return len(self.Slice) }
+
+// Implement `sort.Interface`.
+func (self FixedSlice) Less(one, two int) bool {
+	a, b := self.Slice[one], self.Slice[two]
+	return Compare(a.Num, a.Frac, b.Num, b.Frac, self.Radix) < 0
+}
+
+// Implement `sort.Interface`.
+func (self FixedSlice) Swap(one, two int) {
+	self.Slice[one], self.Slice[two] = self.Slice[two], self.Slice[one]
+}
+
+/*
+Sorts the values in ascending order via `Compare`. The sort is stable: equal
+values at different precisions, such as 1_0 at frac 1 and 1_00 at frac 2,
+keep their original order.
+*/
+func SortFixed(vals []Fixed, radix uint) {
+	sort.Stable(FixedSlice{vals, radix})
+}
+
+/*
+Compares `low * radix^exp` with `high`, where both are magnitudes. Must be
+called with a valid radix.
+*/
+func cmpScaled(low uint64, exp uint, high uint64, radix uint) int {
+	pow, ok := powUint(radix, exp)
+	if !ok {
+		// The power alone exceeds every `uint64`.
+		if low == 0 {
+			return cmpUint(0, high)
+		}
+		return 1
+	}
+
+	hi, lo := bits.Mul64(low, pow)
+	if hi != 0 {
+		return 1
+	}
+	return cmpUint(lo, high)
+}
+
+func sign(num int64) int {
+	if num < 0 {
+		return -1
+	}
+	if num > 0 {
+		return 1
+	}
+	return 0
+}
+
+func cmpInt(one, two int) int {
+	if one < two {
+		return -1
+	}
+	if one > two {
+		return 1
+	}
+	return 0
+}
+
+func cmpUint(one, two uint64) int {
+	if one < two {
+		return -1
+	}
+	if one > two {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package frac
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCompare(*testing.T) {
+	testCompare(12_3400, 4, 12_34, 2, 10, 0)
+	testCompare(12_3401, 4, 12_34, 2, 10, 1)
+	testCompare(12_3399, 4, 12_34, 2, 10, -1)
+	testCompare(-12_3401, 4, -12_34, 2, 10, -1)
+	testCompare(-12_3399, 4, -12_34, 2, 10, 1)
+	testCompare(0, 0, 0, 100, 10, 0)
+	testCompare(0, 100, 1, 0, 10, -1)
+	testCompare(0, 100, -1, 0, 10, 1)
+	testCompare(-1, 4, 1, 2, 10, -1)
+	testCompare(1, 4, 1, 4, 10, 0)
+	testCompare(5, 1, 1, 1, 2, 1)
+
+	// The widened value exceeds `int64` and `uint64`.
+	testCompare(math.MaxInt64, 18, 1, 0, 10, 1)
+	testCompare(math.MaxInt64, 0, math.MaxInt64, 18, 10, 1)
+	testCompare(math.MinInt64, 0, math.MinInt64, 18, 10, -1)
+	testCompare(1, 0, math.MaxInt64, 100, 10, 1)
+	testCompare(-1, 0, math.MinInt64, 100, 10, -1)
+	testCompare(math.MinInt64, 63, -1, 0, 2, 0)
+	testCompare(math.MinInt64, 64, -1, 1, 2, 0)
+	testCompare(math.MinInt64, 64, -1, 0, 2, 1)
+	testCompare(1, 0, math.MaxInt64, 64, 2, 1)
+
+	testEqual(Equal(12_3400, 4, 12_34, 2, 10), true)
+	testEqual(Equal(12_3401, 4, 12_34, 2, 10), false)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				panic(fmt.Errorf(`expected Compare to panic on unsupported radix`))
+			}
+		}()
+		Compare(1, 0, 1, 0, 65)
+	}()
+}
+
+func TestMinMax(*testing.T) {
+	testMinMax(Min, 12_3401, 4, 12_34, 2, 12_34, 2)
+	testMinMax(Min, 12_3399, 4, 12_34, 2, 12_3399, 4)
+	testMinMax(Min, 12_3400, 4, 12_34, 2, 12_3400, 4)
+	testMinMax(Max, 12_3401, 4, 12_34, 2, 12_3401, 4)
+	testMinMax(Max, 12_3399, 4, 12_34, 2, 12_34, 2)
+	testMinMax(Max, 12_3400, 4, 12_34, 2, 12_3400, 4)
+}
+
+func TestSortFixed(*testing.T) {
+	vals := []Fixed{
+		{12_3401, 4},
+		{12_34, 2},
+		{-1, 0},
+		{12_3400, 4},
+		{0, 10},
+		{math.MaxInt64, 18},
+		{-12_34, 2},
+		{12_340, 3},
+	}
+	SortFixed(vals, 10)
+
+	testEqual(vals, []Fixed{
+		{-12_34, 2},
+		{-1, 0},
+		{0, 10},
+		{math.MaxInt64, 18},
+		{12_34, 2},
+		{12_3400, 4},
+		{12_340, 3},
+		{12_3401, 4},
+	})
+}
+
+func testCompare(a int64, fa uint, b int64, fb uint, radix uint, exp int) {
+	act := Compare(a, fa, b, fb, radix)
+	if act != exp {
+		panic(fmt.Errorf(`expected comparing %v (frac %v) with %v (frac %v) in radix %v to produce %v, got %v`, a, fa, b, fb, radix, exp, act))
+	}
+
+	act = Compare(b, fb, a, fa, radix)
+	if act != -exp {
+		panic(fmt.Errorf(`expected comparing %v (frac %v) with %v (frac %v) in radix %v to produce %v, got %v`, b, fb, a, fa, radix, -exp, act))
+	}
+}
+
+func testMinMax(fun func(int64, uint, int64, uint, uint) (int64, uint), a int64, fa uint, b int64, fb uint, expNum int64, expFrac uint) {
+	num, frac := fun(a, fa, b, fb, 10)
+	if num != expNum || frac != expFrac {
+		panic(fmt.Errorf(`expected %v (frac %v), got %v (frac %v)`, expNum, expFrac, num, frac))
+	}
+}
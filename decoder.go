@@ -0,0 +1,153 @@
+package frac
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+/*
+Split function for `bufio.Scanner` that yields numeric tokens: runs of bytes
+separated by ASCII whitespace or commas. Consecutive separators are treated as
+one. The tokens are not validated; use `Unmarshal` or `ParseOpt.Unmarshal` to
+parse them, or use `Decoder`, which does both.
+*/
+func ScanNums(data []byte, atEOF bool) (int, []byte, error) {
+	start, end := scanNum(data, atEOF)
+	if end < 0 {
+		return start, nil, nil
+	}
+	return end, data[start:end], nil
+}
+
+/*
+Decodes a stream of fractional numbers from an `io.Reader`, using `ParseOpt`
+for each token. Tokens are delimited like in `ScanNums`. Buffers are reused
+between tokens, and parsing doesn't allocate. Errors include the line and
+column of the offending token, both starting at 1, with columns measured in
+bytes. Must be created via `NewDecoder`. Example:
+
+	dec := frac.NewDecoder(file, frac.ParseOpt{Frac: 2, Radix: 10})
+	nums, err := dec.Decode(nil)
+*/
+type Decoder struct {
+	opt     ParseOpt
+	scanner *bufio.Scanner
+	line    int
+	col     int
+	tokLine int
+	tokCol  int
+}
+
+// Creates a `Decoder` that reads from the given source.
+func NewDecoder(src io.Reader, opt ParseOpt) *Decoder {
+	out := &Decoder{opt: opt, line: 1, col: 1}
+	out.scanner = bufio.NewScanner(src)
+	out.scanner.Split(out.split)
+	return out
+}
+
+/*
+Decodes the next number. At the end of the stream, returns `io.EOF`. Other
+errors are either from the underlying reader or from parsing.
+*/
+func (self *Decoder) Next() (int64, error) {
+	if !self.scanner.Scan() {
+		err := self.scanner.Err()
+		if err == nil {
+			err = io.EOF
+		}
+		return 0, err
+	}
+
+	num, err := self.opt.Unmarshal(self.scanner.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf(`line %v, column %v: %w`, self.tokLine, self.tokCol, err)
+	}
+	return num, nil
+}
+
+/*
+Decodes the remaining numbers, passing each to the callback. Stops at the first
+error, either from decoding or from the callback. Reaching the end of the
+stream is not an error.
+*/
+func (self *Decoder) Each(fun func(int64) error) error {
+	for {
+		num, err := self.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = fun(num)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+/*
+Decodes the remaining numbers, appending them to the provided slice and
+returning the result. On error, returns the numbers decoded so far.
+*/
+func (self *Decoder) Decode(buf []int64) ([]int64, error) {
+	err := self.Each(func(num int64) error {
+		buf = append(buf, num)
+		return nil
+	})
+	return buf, err
+}
+
+func (self *Decoder) split(data []byte, atEOF bool) (int, []byte, error) {
+	start, end := scanNum(data, atEOF)
+
+	for _, char := range data[:start] {
+		if char == '\n' {
+			self.line++
+			self.col = 1
+		} else {
+			self.col++
+		}
+	}
+
+	if end < 0 {
+		return start, nil, nil
+	}
+
+	self.tokLine, self.tokCol = self.line, self.col
+	self.col += end - start
+	return end, data[start:end], nil
+}
+
+/*
+Returns the bounds of the next token. When the token is incomplete or missing,
+the end is -1, and the start is the amount of separators to skip.
+*/
+func scanNum(data []byte, atEOF bool) (int, int) {
+	start := 0
+	for start < len(data) && isNumSep(data[start]) {
+		start++
+	}
+
+	end := start
+	for end < len(data) && !isNumSep(data[end]) {
+		end++
+	}
+
+	if end < len(data) || (atEOF && end > start) {
+		return start, end
+	}
+	return start, -1
+}
+
+func isNumSep(char byte) bool {
+	switch char {
+	case ' ', '\t', '\n', '\v', '\f', '\r', ',':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,148 @@
+package frac
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func BenchmarkDecoder(b *testing.B) {
+	src := strings.Repeat("-01230.0456, 12.5\n", 64)
+	b.SetBytes(int64(len(src)))
+	buf := make([]int64, 0, 128)
+
+	for range counter(b.N) {
+		var err error
+		buf, err = NewDecoder(strings.NewReader(src), ParseOpt{Frac: 4, Radix: 10}).Decode(buf[:0])
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestScanNums(*testing.T) {
+	testScanNums(``)
+	testScanNums(" \n,\t")
+	testScanNums(`12.5`, `12.5`)
+	testScanNums(" 12.5 ,-3\n\n+0.25,,x ", `12.5`, `-3`, `+0.25`, `x`)
+	testScanNums("1\r\n2\r\n", `1`, `2`)
+}
+
+func TestDecoder(t *testing.T) {
+	const src = " 12.5, -3\n\n+0.25\t0x10,\n1_0 "
+	opt := ParseOpt{Frac: 2, Radix: 10, Prefix: true, Underscore: true}
+
+	t.Run(`decode`, func(*testing.T) {
+		testDecode(src, opt, []int64{12_50, -3_00, 25, 0x10_00, 10_00})
+		testDecode(``, opt, nil)
+		testDecode(" \n ", opt, nil)
+	})
+
+	t.Run(`one_byte_reader`, func(*testing.T) {
+		act, err := NewDecoder(iotest.OneByteReader(strings.NewReader(src)), opt).Decode(nil)
+		if err != nil {
+			panic(err)
+		}
+		testEqual(act, []int64{12_50, -3_00, 25, 0x10_00, 10_00})
+	})
+
+	t.Run(`next`, func(*testing.T) {
+		dec := NewDecoder(strings.NewReader(`1 2`), opt)
+		testNext(dec, 1_00, nil)
+		testNext(dec, 2_00, nil)
+		testNext(dec, 0, io.EOF)
+		testNext(dec, 0, io.EOF)
+	})
+
+	t.Run(`reuse`, func(*testing.T) {
+		buf := make([]int64, 1, 8)
+		act, err := NewDecoder(strings.NewReader(`1 2`), opt).Decode(buf)
+		if err != nil {
+			panic(err)
+		}
+		testEqual(act, []int64{0, 1_00, 2_00})
+		if &act[0] != &buf[0] {
+			panic(`expected Decode to reuse the provided slice`)
+		}
+	})
+
+	t.Run(`position`, func(*testing.T) {
+		testDecodeErr("1\n  2.555", opt, []int64{1_00}, `line 2, column 3: unable to parse "2.555"`)
+		testDecodeErr("1, 2,\n\n,3,  x4", opt, []int64{1_00, 2_00, 3_00}, `line 3, column 6: unable to parse "x4"`)
+		testDecodeErr(`12.5 1__0`, opt, []int64{12_50}, `line 1, column 6:`)
+	})
+
+	t.Run(`callback`, func(*testing.T) {
+		errStop := errors.New(`stop`)
+		var act []int64
+		err := NewDecoder(strings.NewReader(src), opt).Each(func(num int64) error {
+			act = append(act, num)
+			if len(act) == 2 {
+				return errStop
+			}
+			return nil
+		})
+		if err != errStop {
+			panic(fmt.Errorf(`expected callback error, got %v`, err))
+		}
+		testEqual(act, []int64{12_50, -3_00})
+	})
+
+	t.Run(`reader_error`, func(*testing.T) {
+		_, err := NewDecoder(iotest.ErrReader(iotest.ErrTimeout), opt).Decode(nil)
+		if err != iotest.ErrTimeout {
+			panic(fmt.Errorf(`expected reader error, got %v`, err))
+		}
+	})
+}
+
+func testScanNums(src string, exp ...string) {
+	scan := bufio.NewScanner(strings.NewReader(src))
+	scan.Split(ScanNums)
+
+	var act []string
+	for scan.Scan() {
+		act = append(act, scan.Text())
+	}
+	if scan.Err() != nil {
+		panic(scan.Err())
+	}
+	testEqual(act, exp)
+}
+
+func testDecode(src string, opt ParseOpt, exp []int64) {
+	act, err := NewDecoder(strings.NewReader(src), opt).Decode(nil)
+	if err != nil {
+		panic(fmt.Errorf(`failed to decode %q: %+v`, src, err))
+	}
+	testEqual(act, exp)
+}
+
+func testDecodeErr(src string, opt ParseOpt, exp []int64, msg string) {
+	act, err := NewDecoder(strings.NewReader(src), opt).Decode(nil)
+	if err == nil {
+		panic(fmt.Errorf(`expected decoding %q to fail; instead got %v`, src, act))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from decoding %q to contain %q, got %q`, src, msg, err))
+	}
+	testEqual(act, exp)
+}
+
+func testNext(dec *Decoder, exp int64, expErr error) {
+	act, err := dec.Next()
+	if act != exp || err != expErr {
+		panic(fmt.Errorf(`expected Next to return %v, %v; got %v, %v`, exp, expErr, act, err))
+	}
+}
+
+func testEqual(act, exp interface{}) {
+	if !reflect.DeepEqual(act, exp) {
+		panic(fmt.Errorf(`expected %#v, got %#v`, exp, act))
+	}
+}
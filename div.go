@@ -0,0 +1,225 @@
+package frac
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+/*
+Divides two scaled integers of the same fractional precision, returning the
+quotient at that precision, truncated toward zero. `exact` reports whether the
+quotient is exact, which is the case when the expansion of `a/b` in the given
+radix terminates within `frac` digits. For example, in radix 10 at frac 2,
+`DivExact(1_00, 4_00, 2, 10)` is 25 ("0.25") and exact, while
+`DivExact(1_00, 3_00, 2, 10)` is 33 ("0.33") and inexact.
+
+Returns an error wrapping `ErrRange` when the quotient doesn't fit into
+`int64`, and an error for a zero divisor. Supports radixes up to 64, matching
+`Alphabet`.
+*/
+func DivExact(a int64, b int64, frac uint, radix uint) (q int64, exact bool, err error) {
+	if !validRadixAny(radix) {
+		return 0, false, errorf(ErrRadix, `unable to divide %v by %v: unsupported radix %v`, a, b, radix)
+	}
+	if b == 0 {
+		return 0, false, fmt.Errorf(`unable to divide %v by %v: division by zero`, a, b)
+	}
+
+	neg := (a < 0) != (b < 0)
+	quo, rem, ok := divScaled(magnitude(a), magnitude(b), frac, radix)
+	if ok {
+		q, ok = fromMagnitude(quo, neg)
+	}
+	if !ok {
+		return 0, false, errorf(
+			ErrRange,
+			`unable to divide %v by %v (frac %v, radix %v): %v of int64`,
+			a, b, frac, radix, rangeName(rangeCode(neg)),
+		)
+	}
+	return q, rem == 0, nil
+}
+
+/*
+Formats the rational number `num/den` with up to `frac` fractional digits in
+the given radix, truncating toward zero. As with `Format`, trailing zeros are
+omitted. For example, `FormatRat(1, 8, 2, 10)` is "0.12", and
+`FormatRat(-7, 2, 2, 10)` is "-3.5". Unlike `DivExact`, the result isn't
+limited to `int64`: `FormatRat(1, 3, 20, 10)` has 20 fractional digits. To mark
+repeating digits, use `FormatOpt.Repeat`.
+*/
+func FormatRat(num int64, den int64, frac uint, radix uint) (string, error) {
+	return FormatOpt{Frac: frac, Radix: radix}.FormatRat(num, den)
+}
+
+/*
+Same as `FormatRat` but appends the resulting text to the provided buffer,
+returning the resulting union. When there's an error, the buffer is returned
+as-is with no hidden modifications.
+*/
+func AppendRat(buf []byte, num int64, den int64, frac uint, radix uint) ([]byte, error) {
+	return FormatOpt{Frac: frac, Radix: radix}.AppendRat(buf, num, den)
+}
+
+// Same as `FormatRat` but uses the options.
+func (self FormatOpt) FormatRat(num int64, den int64) (string, error) {
+	buf, err := self.AppendRat(nil, num, den)
+	return bytesToMutableString(buf), err
+}
+
+/*
+Same as `AppendRat` but uses the options. `Frac` is the maximum amount of
+fractional digits. When `Repeat` is set and the expansion of `num/den` repeats
+within `Frac` digits, the repeating digits are written once, in parentheses:
+1/3 is "0.(3)", 1/6 is "0.1(6)" and 1/7 at frac 6 or more is "0.(142857)". In
+this case, `MinFrac` is ignored. Expansions that terminate, or repeat with a
+longer period, are truncated to `Frac` digits as usual. Other options apply as
+in `FormatOpt.Append`; `Width` includes the parentheses, and `Zero` applies
+when the truncated result is zero.
+*/
+func (self FormatOpt) AppendRat(buf []byte, num int64, den int64) ([]byte, error) {
+	err := self.Validate()
+	if err == nil && den == 0 {
+		err = fmt.Errorf(`division by zero`)
+	}
+	if err != nil {
+		return buf, fmt.Errorf(`unable to format %v/%v: %w`, num, den, err)
+	}
+
+	mag, dmag := magnitude(num), magnitude(den)
+	whole, rem := mag/dmag, mag%dmag
+
+	count, size := self.Frac, uint(0)
+	if self.Repeat {
+		digits, period := expansion(rem, dmag, self.Frac, self.Radix)
+		if period > 0 {
+			count, size = digits, period
+		}
+	}
+
+	// The whole part is written like in `FormatOpt.Append`, ending at the
+	// middle of the buffer. The fractional digits follow, produced by long
+	// division on the remainder, so their amount isn't limited by `int64`.
+	var local [int(fracMax)*2 + len(`.()`)]byte
+	ind := int(fracMax)
+	table := self.digits()
+	rad := uint64(self.Radix)
+	var digit uint64
+
+	for whole >= rad {
+		whole, digit = pop(whole, rad)
+		ind--
+		local[ind] = table[digit]
+	}
+	ind--
+	local[ind] = table[whole]
+
+	body := append(local[ind:fracMax], '.')
+	point := len(body)
+	used := point
+
+	for pos := uint(0); pos < count; pos++ {
+		if size > 0 && pos == count-size {
+			body = append(body, '(')
+		}
+
+		hi, lo := bits.Mul64(rem, rad)
+		digit, rem = bits.Div64(hi, lo, dmag)
+
+		body = append(body, table[digit])
+		if digit != 0 {
+			used = len(body)
+		}
+	}
+
+	// Like `Append`, a result truncated to zero has no sign.
+	zero := mag < dmag && used == point
+	if zero && self.Zero != `` {
+		return self.appendZero(buf), nil
+	}
+
+	var zeros uint
+	if size > 0 {
+		body = append(body, ')')
+	} else {
+		// Trailing zeros are omitted, except those required by `MinFrac`.
+		keep := point + int(self.MinFrac)
+		if keep > len(body) {
+			keep = len(body)
+		}
+		if used < keep {
+			used = keep
+		}
+		body = body[:used]
+		if used == point {
+			body = body[:point-1]
+		}
+		zeros = self.zeros()
+	}
+
+	neg := (num < 0) != (den < 0) && !zero
+	return self.appendBody(buf, neg, body, zeros, self.Frac == 0), nil
+}
+
+/*
+Computes `num * radix^frac / den` by long division, returning the quotient
+truncated toward zero and the remainder, which is zero when the quotient is
+exact. Reports false when the quotient doesn't fit into `uint64`. Requires a
+non-zero divisor. The loop is bounded regardless of `frac`: a non-zero
+remainder produces a non-zero digit within 64 steps, after which the quotient
+overflows within 64 more.
+*/
+func divScaled(num uint64, den uint64, frac uint, radix uint) (quo uint64, rem uint64, ok bool) {
+	quo, rem = num/den, num%den
+	rad := uint64(radix)
+
+	for ; frac > 0; frac-- {
+		if quo == 0 && rem == 0 {
+			break
+		}
+
+		hi, lo := bits.Mul64(quo, rad)
+		if hi != 0 {
+			return 0, 0, false
+		}
+
+		// The remainder is below the divisor, so the high half of the product
+		// is too, as required by `bits.Div64`.
+		var digit, carry uint64
+		hi, low := bits.Mul64(rem, rad)
+		digit, rem = bits.Div64(hi, low, den)
+		quo, carry = bits.Add64(lo, digit, 0)
+		if carry != 0 {
+			return 0, 0, false
+		}
+	}
+	return quo, rem, true
+}
+
+/*
+Examines the fractional expansion that starts with the given remainder of a
+division by `den`, up to `frac` digits. Returns the amount of fractional digits
+required to render the expansion, and the amount of trailing repeating digits
+among them. When the expansion terminates within `frac` digits, `size` is 0
+and `digits` is its length. When it repeats within `frac` digits, `digits`
+includes one full period. Otherwise `digits` is `frac` and `size` is 0.
+Requires `frac <= fracMax`.
+*/
+func expansion(rem uint64, den uint64, frac uint, radix uint) (digits uint, size uint) {
+	var rems [fracMax + 1]uint64
+
+	for ind := uint(0); ind <= frac; ind++ {
+		if rem == 0 {
+			return ind, 0
+		}
+		for prev := uint(0); prev < ind; prev++ {
+			if rems[prev] == rem {
+				return ind, ind - prev
+			}
+		}
+		rems[ind] = rem
+		hi, lo := bits.Mul64(rem, uint64(radix))
+		_, rem = bits.Div64(hi, lo, den)
+	}
+	return frac, 0
+}
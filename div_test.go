@@ -0,0 +1,224 @@
+package frac
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestDivExact(*testing.T) {
+	testDivExact(1_00, 4_00, 2, 10, 25, true)
+	testDivExact(1_00, 3_00, 2, 10, 33, false)
+	testDivExact(2_00, 3_00, 2, 10, 66, false)
+	testDivExact(-2_00, 3_00, 2, 10, -66, false)
+	testDivExact(2_00, -3_00, 2, 10, -66, false)
+	testDivExact(-2_00, -3_00, 2, 10, 66, false)
+	testDivExact(10_00, 25, 2, 10, 40_00, true)
+	testDivExact(0, 7, 1000, 10, 0, true)
+	testDivExact(7, 7, 0, 10, 1, true)
+	testDivExact(7, 2, 0, 10, 3, false)
+	testDivExact(1, 3, 18, 10, 333_333_333_333_333_333, false)
+	testDivExact(0b1, 0b11, 4, 2, 0b0101, false)
+	testDivExact(0x1, 0x10, 2, 16, 0x10, true)
+	testDivExact(1, 3, 1, 3, 1, true)
+	testDivExact(math.MinInt64, 1, 0, 10, math.MinInt64, true)
+	testDivExact(math.MinInt64, math.MinInt64, 18, 10, 1e18, true)
+	testDivExact(math.MaxInt64, math.MinInt64, 0, 10, 0, false)
+
+	testDivExactErr(1, 0, 2, 10, nil, `division by zero`)
+	testDivExactErr(1, 1, 19, 10, ErrRange, `overflow of int64`)
+	testDivExactErr(-1, 1, 1000, 10, ErrRange, `underflow of int64`)
+	testDivExactErr(1, 3, 40, 10, ErrRange, `overflow of int64`)
+	testDivExactErr(math.MinInt64, -1, 0, 10, ErrRange, `overflow of int64`)
+	testDivExactErr(1, 1, 2, 65, ErrRadix, `unsupported radix 65`)
+}
+
+func TestFormatRat(*testing.T) {
+	testFormatRat(1, 8, 2, 10, `0.12`)
+	testFormatRat(1, 8, 3, 10, `0.125`)
+	testFormatRat(1, 8, 64, 10, `0.125`)
+	testFormatRat(1, 1, 64, 10, `1`)
+	testFormatRat(-7, 2, 2, 10, `-3.5`)
+	testFormatRat(7, -2, 0, 10, `-3`)
+	testFormatRat(-1, 3, 0, 10, `0`)
+	testFormatRat(0, -5, 2, 10, `0`)
+	testFormatRat(1, 3, 5, 10, `0.33333`)
+	testFormatRat(1, 3, 1, 3, `0.1`)
+	testFormatRat(1, 10, 8, 2, `0.00011001`)
+	testFormatRat(255, 16, 2, 16, `f.f`)
+	testFormatRat(math.MinInt64, 1, 0, 10, `-9223372036854775808`)
+	testFormatRat(math.MinInt64, -1, 0, 10, `9223372036854775808`)
+	testFormatRat(math.MinInt64, -1, 64, 2, `1000000000000000000000000000000000000000000000000000000000000000`)
+	testFormatRat(1, math.MinInt64, 64, 2, `-0.000000000000000000000000000000000000000000000000000000000000001`)
+	testFormatRat(-1, 1000, 2, 10, `0`)
+
+	// The result doesn't have to fit into `int64`.
+	testFormatRat(1, 3, 20, 10, `0.33333333333333333333`)
+	testFormatRat(-1, 3, 64, 10, `-0.`+strings.Repeat(`3`, 64))
+	testFormatRat(1_000_000_000_000, 3, 8, 10, `333333333333.33333333`)
+	testFormatRat(math.MaxInt64, 7, 40, 10, `1317624576693539401`)
+	testFormatRat(math.MaxInt64, 9, 30, 10, `1024819115206086200.777777777777777777777777777777`)
+
+	testFormatRatErr(FormatOpt{Frac: 2, Radix: 10}, 1, 0, `division by zero`)
+	testFormatRatErr(FormatOpt{Frac: 2, Radix: 37}, 1, 1, `unsupported radix 37`)
+	testFormatRatErr(FormatOpt{Frac: 65, Radix: 10}, 1, 1, `exceeds limit 64`)
+}
+
+// Differential test against `big.Int`, which computes the truncated digits of
+// `num * radix^frac / den` exactly.
+func TestFormatRatBig(*testing.T) {
+	nums := []int64{0, 1, -1, 2, 7, -10, 12345, 1_000_000_007, math.MaxInt64, math.MinInt64}
+	dens := []int64{1, -1, 2, 3, 7, -9, 11, 1000, 65537, math.MaxInt64, math.MinInt64}
+
+	for _, radix := range []uint{2, 3, 10, 16, 36} {
+		for _, frac := range []uint{0, 1, 5, 19, 40, 64} {
+			for _, num := range nums {
+				for _, den := range dens {
+					testFormatRatBig(num, den, frac, radix)
+				}
+			}
+		}
+	}
+}
+
+func TestFormatOptRat(*testing.T) {
+	repeat := FormatOpt{Frac: 10, Radix: 10, Repeat: true}
+
+	testFormatOptRat(repeat, 1, 3, `0.(3)`)
+	testFormatOptRat(repeat, -1, 3, `-0.(3)`)
+	testFormatOptRat(repeat, 2, 3, `0.(6)`)
+	testFormatOptRat(repeat, 1, 6, `0.1(6)`)
+	testFormatOptRat(repeat, 1, 7, `0.(142857)`)
+	testFormatOptRat(repeat, 1, 11, `0.(09)`)
+	testFormatOptRat(repeat, 1, 12, `0.08(3)`)
+	testFormatOptRat(repeat, 22, 7, `3.(142857)`)
+	testFormatOptRat(repeat, 1, 8, `0.125`)
+	testFormatOptRat(repeat, 4, 2, `2`)
+	testFormatOptRat(repeat, 0, 3, `0`)
+	testFormatOptRat(repeat, 1, 17, `0.0588235294`)
+	testFormatOptRat(FormatOpt{Frac: 16, Radix: 10, Repeat: true}, 1, 17, `0.(0588235294117647)`)
+	testFormatOptRat(FormatOpt{Frac: 30, Radix: 10, Repeat: true}, 1, 23, `0.(0434782608695652173913)`)
+	testFormatOptRat(FormatOpt{Frac: 64, Radix: 10, Repeat: true}, math.MaxInt64, 3, `3074457345618258602.(3)`)
+	testFormatOptRat(FormatOpt{Frac: 5, Radix: 10, Repeat: true}, 1, 7, `0.14285`)
+	testFormatOptRat(FormatOpt{Frac: 6, Radix: 10, Repeat: true}, 1, 7, `0.(142857)`)
+	testFormatOptRat(FormatOpt{Frac: 1, Radix: 10, Repeat: true}, 1, 3, `0.(3)`)
+	testFormatOptRat(FormatOpt{Frac: 0, Radix: 10, Repeat: true}, 1, 3, `0`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 2, Repeat: true}, 1, 3, `0.(01)`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 3, Repeat: true}, 1, 3, `0.1`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 16, Repeat: true, Upper: true}, 1, 15, `0.(1)`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 16, Repeat: true, Upper: true}, 11, 3, `3.(A)`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 16, Repeat: true, Prefix: true}, 11, 3, `0x3.(a)`)
+
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, Repeat: true, MinFrac: 4}, 1, 3, `0.(3)`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, Repeat: true, MinFrac: 4}, 1, 4, `0.2500`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, Repeat: true, Sign: SignPlus}, 1, 3, `+0.(3)`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, Repeat: true, Width: 8}, 1, 3, `   0.(3)`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, Repeat: true, Width: 8, Left: true}, 1, 3, `0.(3)   `)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, Repeat: true, Width: 8, ZeroPad: true}, -1, 3, `-000.(3)`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, Repeat: true, Width: 2}, 1, 3, `0.(3)`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, Width: 8}, 1, 3, `  0.3333`)
+	testFormatOptRat(FormatOpt{Frac: 2, Radix: 10, MinFrac: 2}, -1, 1000, `0.00`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, MinFrac: 6}, 1, 8, `0.125000`)
+	testFormatOptRat(FormatOpt{Frac: 2, Radix: 10, Zero: `-`}, -1, 1000, `-`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 10, Zero: `-`}, 1, 1000, `0.001`)
+	testFormatOptRat(FormatOpt{Frac: 4, Radix: 62, Alphabet: AlphabetBase62}, 1, 2, `0.v`)
+
+	testFormatOptRatAppend(repeat, `total: `, 1, 3, `total: 0.(3)`)
+	testFormatOptRatAppend(FormatOpt{Frac: 4, Radix: 10, Repeat: true, Width: 7, Left: true}, `<`, 1, 6, `<0.1(6) `)
+}
+
+func testDivExact(a, b int64, frac, radix uint, exp int64, expExact bool) {
+	act, exact, err := DivExact(a, b, frac, radix)
+	if err != nil {
+		panic(fmt.Errorf(`failed to divide %v by %v (frac %v, radix %v): %+v`, a, b, frac, radix, err))
+	}
+	if act != exp || exact != expExact {
+		panic(fmt.Errorf(
+			`expected dividing %v by %v (frac %v, radix %v) to produce %v (exact %v), got %v (exact %v)`,
+			a, b, frac, radix, exp, expExact, act, exact,
+		))
+	}
+}
+
+func testDivExactErr(a, b int64, frac, radix uint, kind error, msg string) {
+	res, _, err := DivExact(a, b, frac, radix)
+	if err == nil {
+		panic(fmt.Errorf(`expected dividing %v by %v to fail; instead got %v`, a, b, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from dividing %v by %v to contain %q, got %q`, a, b, msg, err))
+	}
+	if kind != nil && !errors.Is(err, kind) {
+		panic(fmt.Errorf(`expected error from dividing %v by %v to be %v, got %q`, a, b, kind, err))
+	}
+}
+
+func testFormatRat(num, den int64, frac, radix uint, exp string) {
+	act, err := FormatRat(num, den, frac, radix)
+	if err != nil {
+		panic(fmt.Errorf(`failed to format %v/%v (frac %v, radix %v): %+v`, num, den, frac, radix, err))
+	}
+	if exp != act {
+		panic(fmt.Errorf(`expected to format %v/%v (frac %v, radix %v) into %q, got %q`, num, den, frac, radix, exp, act))
+	}
+}
+
+func testFormatRatBig(num, den int64, frac, radix uint) {
+	act, err := FormatRat(num, den, frac, radix)
+	if err != nil {
+		panic(fmt.Errorf(`failed to format %v/%v (frac %v, radix %v): %+v`, num, den, frac, radix, err))
+	}
+
+	quo := new(big.Int).Exp(big.NewInt(int64(radix)), big.NewInt(int64(frac)), nil)
+	quo.Mul(quo, big.NewInt(num))
+	quo.Quo(quo, big.NewInt(den))
+
+	text := new(big.Int).Abs(quo).Text(int(radix))
+	if uint(len(text)) <= frac {
+		text = strings.Repeat(`0`, int(frac)-len(text)+1) + text
+	}
+	whole, part := text[:uint(len(text))-frac], strings.TrimRight(text[uint(len(text))-frac:], `0`)
+
+	exp := whole
+	if part != `` {
+		exp += `.` + part
+	}
+	if quo.Sign() < 0 {
+		exp = `-` + exp
+	}
+
+	if exp != act {
+		panic(fmt.Errorf(`expected to format %v/%v (frac %v, radix %v) into %q, got %q`, num, den, frac, radix, exp, act))
+	}
+}
+
+func testFormatRatErr(opt FormatOpt, num, den int64, msg string) {
+	prefix := []byte(`prefix`)
+	res, err := opt.AppendRat(prefix, num, den)
+	if err == nil {
+		panic(fmt.Errorf(`expected formatting %v/%v (%+v) to fail; instead got %q`, num, den, opt, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from formatting %v/%v (%+v) to contain %q, got %q`, num, den, opt, msg, err))
+	}
+	if string(res) != `prefix` {
+		panic(fmt.Errorf(`expected failed formatting to return the buffer as-is, got %q`, res))
+	}
+}
+
+func testFormatOptRat(opt FormatOpt, num, den int64, exp string) {
+	testFormatOptRatAppend(opt, ``, num, den, exp)
+}
+
+func testFormatOptRatAppend(opt FormatOpt, prefix string, num, den int64, exp string) {
+	buf, err := opt.AppendRat([]byte(prefix), num, den)
+	if err != nil {
+		panic(fmt.Errorf(`failed to format %v/%v (%+v): %+v`, num, den, opt, err))
+	}
+	if act := string(buf); exp != act {
+		panic(fmt.Errorf(`expected to format %v/%v (%+v) into %q, got %q`, num, den, opt, exp, act))
+	}
+}
@@ -0,0 +1,118 @@
+package frac
+
+import "flag"
+
+/*
+Returns a `flag.Value` that parses into and formats from the given pointer,
+using `Parse` and `Format` with the given precision and radix. For example,
+with `Flag(&limit, 2, 10)`, the flag "-max-order=2500.00" sets `limit` to
+2500_00. The result is a `*FlagValue`, which also implements `flag.Getter`,
+the `pflag.Value` interface, and text encoding interfaces; see `FlagValue`.
+*/
+func Flag(ptr *int64, frac uint, radix uint) flag.Value {
+	return &FlagValue{Ptr: ptr, Frac: frac, Radix: radix}
+}
+
+/*
+Defines a flag on the given flag set, which parses into the given pointer,
+similar to `flag.Int64Var`. The pointer is initialized with the given default
+value, which is shown in the usage text as a fractional number. When the flag
+set is nil, uses `flag.CommandLine`.
+*/
+func FlagVar(set *flag.FlagSet, ptr *int64, name string, val int64, frac uint, radix uint, usage string) {
+	*ptr = val
+	flagSet(set).Var(Flag(ptr, frac, radix), name, usage)
+}
+
+/*
+Defines a flag on the given flag set, which parses each value and calls the
+given function with the result, similar to `flag.Func`. When the flag set is
+nil, uses `flag.CommandLine`.
+*/
+func FlagFunc(set *flag.FlagSet, name string, frac uint, radix uint, usage string, fun func(int64) error) {
+	flagSet(set).Func(name, usage, func(src string) error {
+		num, err := Parse(src, frac, radix)
+		if err != nil {
+			return err
+		}
+		return fun(num)
+	})
+}
+
+func flagSet(set *flag.FlagSet) *flag.FlagSet {
+	if set == nil {
+		return flag.CommandLine
+	}
+	return set
+}
+
+/*
+Adapter between a scaled integer and text-based configuration such as CLI
+flags and environment variables. `Set` and `UnmarshalText` parse into `*Ptr`
+via `Parse`, while `String` and `MarshalText` format `*Ptr` via `Format`. Can be
+used directly with env loaders that support `encoding.TextUnmarshaler`:
+
+	var fee int64
+	err := frac.FlagValue{Ptr: &fee, Frac: 4, Radix: 10}.UnmarshalText([]byte(os.Getenv(`FEE_RATE`)))
+
+Implements `flag.Value`, `flag.Getter`, the `pflag.Value` interface, which
+additionally requires `Type`, and `encoding.TextMarshaler` and
+`encoding.TextUnmarshaler`. `Set` and `UnmarshalText` require a non-nil `Ptr`.
+*/
+type FlagValue struct {
+	Ptr   *int64
+	Frac  uint
+	Radix uint
+}
+
+/*
+Implement `fmt.Stringer` and `flag.Value`. Returns "0" when `Ptr` is nil, which
+allows `flag` to detect default values, and an empty string when the options
+are invalid.
+*/
+func (self FlagValue) String() string {
+	if self.Ptr == nil {
+		return `0`
+	}
+	out, _ := Format(*self.Ptr, self.Frac, self.Radix)
+	return out
+}
+
+// Implement `flag.Value`. Parses the input into `*Ptr`.
+func (self FlagValue) Set(src string) error {
+	num, err := Parse(src, self.Frac, self.Radix)
+	if err != nil {
+		return err
+	}
+	*self.Ptr = num
+	return nil
+}
+
+// Implement `flag.Getter`. Returns `*Ptr` as `int64`.
+func (self FlagValue) Get() interface{} {
+	if self.Ptr == nil {
+		return int64(0)
+	}
+	return *self.Ptr
+}
+
+// Implement the `pflag.Value` interface. Describes the type in usage text.
+func (self FlagValue) Type() string { return `frac` }
+
+// Implement `encoding.TextMarshaler`.
+func (self FlagValue) MarshalText() ([]byte, error) {
+	if self.Ptr == nil {
+		return []byte(`0`), nil
+	}
+	return Append(nil, *self.Ptr, self.Frac, self.Radix)
+}
+
+// Implement `encoding.TextUnmarshaler`. Parses the input into `*Ptr`.
+func (self FlagValue) UnmarshalText(src []byte) error {
+	num, err := Unmarshal(src, self.Frac, self.Radix)
+	if err != nil {
+		return err
+	}
+	*self.Ptr = num
+	return nil
+}
@@ -0,0 +1,104 @@
+package frac
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	_ flag.Getter                = FlagValue{}
+	_ encoding.TextMarshaler     = FlagValue{}
+	_ encoding.TextUnmarshaler   = FlagValue{}
+	_ interface{ Type() string } = FlagValue{}
+)
+
+func TestFlag(*testing.T) {
+	var limit, rate, mask int64
+	var fees []int64
+
+	set := flag.NewFlagSet(`test`, flag.ContinueOnError)
+	set.SetOutput(io.Discard)
+	FlagVar(set, &limit, `max-order`, 1000_00, 2, 10, `max order`)
+	set.Var(Flag(&rate, 4, 10), `fee-rate`, `fee rate`)
+	set.Var(Flag(&mask, 1, 16), `mask`, `mask`)
+	FlagFunc(set, `fee`, 2, 10, `fee`, func(num int64) error {
+		if num < 0 {
+			return fmt.Errorf(`negative fee`)
+		}
+		fees = append(fees, num)
+		return nil
+	})
+
+	testEqual(limit, int64(1000_00))
+	testEqual(set.Lookup(`max-order`).DefValue, `1000`)
+	testEqual(set.Lookup(`fee-rate`).DefValue, `0`)
+
+	err := set.Parse([]string{`-max-order=2500.00`, `-fee-rate`, `0.0025`, `-mask=-ff.8`, `-fee=1.5`, `-fee`, `2`})
+	if err != nil {
+		panic(err)
+	}
+	testEqual(limit, int64(2500_00))
+	testEqual(rate, int64(25))
+	testEqual(mask, int64(-0xff_8))
+	testEqual(fees, []int64{1_50, 2_00})
+	testEqual(set.Lookup(`max-order`).Value.String(), `2500`)
+	testEqual(set.Lookup(`fee-rate`).Value.(flag.Getter).Get(), int64(25))
+
+	testFlagErr(set, `-max-order=1.255`, `exponent exceeds`)
+	testFlagErr(set, `-fee=-1`, `negative fee`)
+	testFlagErr(set, `-fee=x`, `non-digit character 'x'`)
+	testEqual(limit, int64(2500_00))
+
+	var usage strings.Builder
+	set.SetOutput(&usage)
+	set.PrintDefaults()
+	if !strings.Contains(usage.String(), `max order (default 1000)`) || strings.Contains(usage.String(), `fee rate (default`) {
+		panic(fmt.Errorf(`unexpected usage text: %q`, usage.String()))
+	}
+}
+
+func TestFlagValue(*testing.T) {
+	var num int64
+	val := FlagValue{Ptr: &num, Frac: 4, Radix: 10}
+
+	err := val.UnmarshalText([]byte(`0.0025`))
+	if err != nil {
+		panic(err)
+	}
+	testEqual(num, int64(25))
+
+	text, err := val.MarshalText()
+	if err != nil {
+		panic(err)
+	}
+	testEqual(string(text), `0.0025`)
+	testEqual(val.String(), `0.0025`)
+	testEqual(val.Type(), `frac`)
+
+	err = val.UnmarshalText([]byte(`0.00255`))
+	if !errors.Is(err, ErrPrecision) {
+		panic(fmt.Errorf(`expected ErrPrecision, got %v`, err))
+	}
+	testEqual(num, int64(25))
+
+	testEqual(FlagValue{}.String(), `0`)
+	testEqual(FlagValue{}.Get(), int64(0))
+	testEqual(FlagValue{Ptr: &num, Radix: 37}.String(), ``)
+
+	_, err = FlagValue{Ptr: &num, Radix: 37}.MarshalText()
+	if err == nil {
+		panic(fmt.Errorf(`expected MarshalText to fail for invalid radix`))
+	}
+}
+
+func testFlagErr(set *flag.FlagSet, arg string, msg string) {
+	err := set.Parse([]string{arg})
+	if err == nil || !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected parsing flag %q to fail with %q, got %v`, arg, msg, err))
+	}
+}
@@ -1,14 +1,21 @@
 /*
 Missing feature of the Go standard library: parsing and formatting integers as
 fractional numeric strings, without any rounding or bignums, by using a fixed
-fraction size. Supports arbitrary radixes from 2 to 36.
+fraction size. Supports arbitrary radixes from 2 to 36, or up to 64 with a
+custom `Alphabet`. Functions that don't encode digits, such as `Pow`, accept
+any radix up to 64.
 
 See `readme.md` for examples.
 */
 package frac
 
 import (
+	"errors"
 	"fmt"
+	"math/bits"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 	"unsafe"
 )
 
@@ -43,17 +50,142 @@ allotted precision.
 
 See `readme.md` for examples.
 */
-func Parse(src string, frac uint, radix uint) (num int64, err error) {
-	if len(src) == 0 {
-		return 0, fmt.Errorf(`unable to parse empty input as number`)
+func Parse(src string, frac uint, radix uint) (int64, error) {
+	return ParseOpt{Frac: frac, Radix: radix}.Parse(src)
+}
+
+/*
+Describes which letter case is accepted for digits above 9 when parsing. The
+zero value `CaseAny` matches the default behavior of `Parse`.
+*/
+type Case byte
+
+const (
+	// Both "a" and "A" are accepted as the digit 10.
+	CaseAny Case = iota
+
+	// Only "a" is accepted as the digit 10.
+	CaseLower
+
+	// Only "A" is accepted as the digit 10.
+	CaseUpper
+)
+
+/*
+Parsing options. `Parse` and `Unmarshal` are shortcuts for a `ParseOpt` with
+only `Frac` and `Radix` set. Other fields are optional; their zero values match
+the default behavior.
+
+`Case` controls which letter case is accepted for digits above 9; see `Case`.
+
+When `Alphabet` is set, digits are decoded with the given alphabet rather than
+the default "0-9a-z", and the radix may be as large as the alphabet. In this
+case, `Case` is ignored; the alphabet defines its own case sensitivity.
+
+When `Prefix` is set, the input may have a Go-style radix prefix after the
+optional sign: "0b" or "0B" for radix 2, "0o" or "0O" for radix 8, "0x" or
+"0X" for radix 16. The prefix overrides `Radix`, which is used for inputs
+without a prefix. Unlike `strconv.ParseInt`, a leading "0" alone doesn't
+indicate radix 8, because fractional numbers such as "0.5" start with "0".
+
+When `Underscore` is set, digits may be separated by "_", following the rules
+of Go number literals: "_" may appear only between successive digits, or
+between the radix prefix and the first digit. For example, "1_000_000.00_01"
+and "0x_ff" are accepted, while "_1", "1_", "1__0", "1_.0" and "1._0" are
+rejected. This takes priority over an `Alphabet` that uses "_" as a digit.
+
+When `Lenient` is set, the parser accepts common variations of user input:
+leading and trailing Unicode whitespace is ignored, the integer part may be
+omitted as in ".5" or "-.5", the fractional part may be omitted after the point
+as in "12.", and the sign may be separated from the digits by whitespace as in
+"- 12". At least one digit is still required, and the fractional precision is
+still enforced without rounding.
+*/
+type ParseOpt struct {
+	Frac       uint
+	Radix      uint
+	Case       Case
+	Alphabet   *Alphabet
+	Prefix     bool
+	Underscore bool
+	Lenient    bool
+}
+
+// Same as `Unmarshal` but uses the options.
+func (self ParseOpt) Unmarshal(src []byte) (int64, error) {
+	return self.Parse(bytesToMutableString(src))
+}
+
+// Same as `Parse` but uses the options.
+func (self ParseOpt) Parse(src string) (int64, error) {
+	err := self.validate()
+	if err != nil {
+		return 0, fmt.Errorf(`unable to parse %q as number: %w`, src, err)
 	}
 
-	if !(radix >= radixMin && radix <= radixMax) {
-		return 0, fmt.Errorf(`unable to parse %q as number: unsupported radix %v`, src, radix)
+	if self.plain() {
+		plain := self.plainParser()
+		num, ok := plain.parse(src)
+		if ok {
+			return num, nil
+		}
+	}
+	return self.parse(src)
+}
+
+func (self ParseOpt) validate() error {
+	if !self.validRadix() {
+		return errorf(ErrRadix, `unsupported radix %v`, self.Radix)
+	}
+	if !self.validCase() {
+		return fmt.Errorf(`unsupported case mode %v`, self.Case)
+	}
+	return nil
+}
+
+func (self ParseOpt) validRadix() bool {
+	return self.Radix >= radixMin && self.Radix <= self.Alphabet.radixMax()
+}
+
+// Validates a radix for functions that don't encode digits; see the package doc.
+func validRadixAny(radix uint) bool {
+	return radix >= radixMin && radix <= radixMaxAlphabet
+}
+
+func (self ParseOpt) validCase() bool { return self.Case <= CaseUpper }
+
+// Must be called after `ParseOpt.validate`.
+func (self ParseOpt) parse(src string) (int64, error) {
+	src = self.trim(src)
+	num, fail := self.scan(src)
+	if fail.code != failNone {
+		return 0, fail.err(src, self.Frac)
+	}
+	return num, nil
+}
+
+func (self ParseOpt) trim(src string) string {
+	if self.Lenient {
+		return strings.TrimFunc(src, unicode.IsSpace)
+	}
+	return src
+}
+
+/*
+Parses the input without allocating errors. On failure, the result describes
+the failure; see `parseFail.err`. Must be called after `ParseOpt.validate` and
+`ParseOpt.trim`.
+*/
+func (self ParseOpt) scan(src string) (num int64, fail parseFail) {
+	frac, radix := self.Frac, self.Radix
+
+	if len(src) == 0 {
+		return 0, parseFail{code: failEmpty}
 	}
 
 	var sign int64 = 1
 	var expDigs uint
+	var code parseCode
 
 	const (
 		stepSign = iota
@@ -63,40 +195,73 @@ func Parse(src string, frac uint, radix uint) (num int64, err error) {
 		stepExp
 	)
 	step := stepSign
+	prefix := self.Prefix
+	prefixed := false
+	under := false
+	mant := false
+
+	for ind := 0; ind < len(src); ind++ {
+		char := src[ind]
 
-	for ind, char := range []byte(src) {
 		if step == stepSign {
-			if char == '+' {
+			if char == '+' || char == '-' {
+				if char == '-' {
+					sign = -1
+				}
 				step = stepMantStart
+				if self.Lenient {
+					ind += spacePrefixLen(src[ind+1:])
+				}
 				continue
 			}
 
-			if char == '-' {
-				sign = -1
-				step = stepMantStart
-				continue
+			step = stepMantStart
+		}
+
+		if step == stepMantStart && prefix && ind+1 < len(src) {
+			prefix = false
+
+			if char == '0' {
+				pref := prefixRadix(src[ind+1])
+				if pref != 0 {
+					if !(pref <= self.Alphabet.radixMax()) {
+						return 0, parseFail{code: failRadix, radix: pref}
+					}
+					radix = pref
+					prefixed = true
+					ind++
+					continue
+				}
 			}
+		}
 
-			step = stepMantStart
+		if self.Underscore && char == '_' {
+			if under || !(step == stepMant || step == stepExp || (step == stepMantStart && prefixed)) {
+				return 0, parseFail{code: failUnderscore, radix: radix}
+			}
+			under = true
+			continue
 		}
 
-		if step == stepMant && char == '.' {
+		if (step == stepMant || (step == stepMantStart && self.Lenient)) && char == '.' {
+			if under {
+				return 0, parseFail{code: failUnderscore, radix: radix}
+			}
 			step = stepExpStart
 			continue
 		}
+		under = false
 
 		if step == stepMantStart {
 			step = stepMant
+			mant = true
 		} else if step == stepExpStart {
 			step = stepExp
 		}
 
-		digit := toDigit(char)
+		digit := self.digit(char)
 		if digit == unDigit || uint(digit) >= radix {
-			return 0, fmt.Errorf(
-				`unable to parse %q as number (radix %v, fraction %v): found non-digit character %q`,
-				src, radix, frac, runeAt(src, ind),
-			)
+			return 0, parseFail{code: failNonDigit, ind: ind, radix: radix}
 		}
 
 		if step == stepExp {
@@ -105,10 +270,7 @@ func Parse(src string, frac uint, radix uint) (num int64, err error) {
 				if digit == 0 {
 					continue
 				}
-				return 0, fmt.Errorf(
-					`unable to parse %q as number (radix %v, fraction %v): exponent exceeds allotted fractional precision`,
-					src, radix, frac,
-				)
+				return 0, parseFail{code: failExponent, radix: radix}
 			}
 		}
 
@@ -117,27 +279,370 @@ func Parse(src string, frac uint, radix uint) (num int64, err error) {
 			continue
 		}
 
-		num, err = inc(src, num, radix, sign, digit)
-		if err != nil {
-			return 0, err
+		num, code = inc(num, radix, sign, digit)
+		if code != failNone {
+			return 0, parseFail{code: code, radix: radix}
+		}
+	}
+
+	if expDigs < frac {
+		num, code = scale(num, radix, frac-expDigs)
+		if code != failNone {
+			return 0, parseFail{code: code, radix: radix}
+		}
+	}
+
+	if step != stepMant && step != stepExp && !(step == stepExpStart && mant && self.Lenient) {
+		return 0, parseFail{code: failEnd, radix: radix}
+	}
+	if under {
+		return 0, parseFail{code: failUnderscore, radix: radix}
+	}
+	return num, parseFail{}
+}
+
+// True if the options allow `plainParser`.
+func (self ParseOpt) plain() bool {
+	return !self.Prefix && !self.Underscore && !self.Lenient
+}
+
+/*
+Parser for the plain syntax: an optional sign, digits, and optionally a point
+followed by digits, where digits beyond `frac` must be zeros. Other inputs,
+including all invalid ones, are rejected without an error, and must be parsed
+by `ParseOpt.parse`, which produces the appropriate result. Cheap to create,
+and can be reused for a batch of inputs.
+*/
+type plainParser struct {
+	frac   uint
+	radix  uint64
+	limit  uint64
+	pows   []uint64
+	digits *[256]byte
+}
+
+// Must be called after `ParseOpt.validate` and `ParseOpt.plain`.
+func (self ParseOpt) plainParser() (out plainParser) {
+	out.frac = self.Frac
+	out.radix = uint64(self.Radix)
+	out.limit = uint64(mulLimits[self.Radix].max)
+	out.pows = pows[self.Radix]
+	if self.Alphabet != nil {
+		out.digits = &self.Alphabet.values
+	} else {
+		out.digits = &caseDigits[self.Case]
+	}
+	return
+}
+
+func (self *plainParser) parse(src string) (int64, bool) {
+	ind := 0
+	neg := false
+	if len(src) > 0 && (src[0] == '-' || src[0] == '+') {
+		neg = src[0] == '-'
+		ind++
+	}
+
+	var mag uint64
+	var expDigs uint
+	mant := false
+	point := false
+	exp := false
+
+	for ; ind < len(src); ind++ {
+		char := src[ind]
+		if char == '.' && mant && !point {
+			point = true
+			continue
+		}
+
+		digit := uint64(self.digits[char])
+		if digit >= self.radix {
+			return 0, false
+		}
+
+		if point {
+			exp = true
+			if expDigs == self.frac {
+				// Trailing zeros beyond the precision are allowed.
+				if digit != 0 {
+					return 0, false
+				}
+				continue
+			}
+			expDigs++
+		} else {
+			mant = true
+		}
+
+		// The magnitude stays below `math.MaxInt64 + radix`, so this can't wrap
+		// around, and larger magnitudes are rejected below.
+		if mag > self.limit {
+			return 0, false
 		}
+		mag = mag*self.radix + digit
+	}
+
+	if !mant || (point && !exp) {
+		return 0, false
 	}
 
-	for expDigs < frac {
-		num, err = inc(src, num, radix, sign, 0)
-		if err != nil {
-			return 0, err
+	zeros := self.frac - expDigs
+	if zeros > 0 && mag != 0 {
+		if zeros >= uint(len(self.pows)) {
+			return 0, false
+		}
+		var hi uint64
+		hi, mag = bits.Mul64(mag, self.pows[zeros])
+		if hi != 0 {
+			return 0, false
 		}
-		expDigs++
 	}
+	return fromMagnitude(mag, neg)
+}
+
+func spacePrefixLen(src string) int {
+	return len(src) - len(strings.TrimLeftFunc(src, unicode.IsSpace))
+}
+
+/*
+Sentinel errors describing the kind of a parsing failure. Errors returned by
+`Parse` and related functions wrap one of these, which can be detected via
+`errors.Is`. The error messages are unaffected.
+*/
+var (
+	// The input is not a number: it's empty, has a non-digit character, or
+	// ends prematurely.
+	ErrSyntax = errors.New(`invalid syntax`)
+
+	// The input has more fractional digits than the precision allows.
+	ErrPrecision = errors.New(`excess precision`)
+
+	// The number doesn't fit into `int64`.
+	ErrRange = errors.New(`out of range`)
+
+	// The radix is unsupported, either in the options or in a prefix.
+	ErrRadix = errors.New(`unsupported radix`)
+)
+
+/*
+Error with a preformatted message, which reports a sentinel kind such as
+`ErrSyntax` via `errors.Is` without including it in the message.
+*/
+type kindError struct {
+	msg  string
+	kind error
+}
+
+func (self kindError) Error() string { return self.msg }
+
+func (self kindError) Unwrap() error { return self.kind }
+
+func errorf(kind error, pattern string, args ...interface{}) error {
+	return kindError{fmt.Sprintf(pattern, args...), kind}
+}
+
+type parseCode byte
+
+const (
+	failNone parseCode = iota
+	failEmpty
+	failRadix
+	failNonDigit
+	failUnderscore
+	failExponent
+	failEnd
+	failOverflow
+	failUnderflow
+)
+
+/*
+Describes a parsing failure. `ind` is the position of the offending character,
+if any. `radix` is the radix in effect, which may come from a prefix.
+*/
+type parseFail struct {
+	code  parseCode
+	ind   int
+	radix uint
+}
+
+func (self parseFail) err(src string, frac uint) error {
+	switch self.code {
+	case failNone:
+		return nil
+
+	case failEmpty:
+		return errorf(ErrSyntax, `unable to parse empty input as number`)
 
-	if step != stepMant && step != stepExp {
-		return 0, fmt.Errorf(
+	case failRadix:
+		return errorf(ErrRadix, `unable to parse %q as number: unsupported radix %v`, src, self.radix)
+
+	case failNonDigit:
+		return errorf(
+			ErrSyntax,
+			`unable to parse %q as number (radix %v, fraction %v): found non-digit character %q`,
+			src, self.radix, frac, runeAt(src, self.ind),
+		)
+
+	case failUnderscore:
+		return errorf(
+			ErrSyntax,
+			`unable to parse %q as number (radix %v, fraction %v): "_" must separate successive digits`,
+			src, self.radix, frac,
+		)
+
+	case failExponent:
+		return errorf(
+			ErrPrecision,
+			`unable to parse %q as number (radix %v, fraction %v): exponent exceeds allotted fractional precision`,
+			src, self.radix, frac,
+		)
+
+	case failEnd:
+		return errorf(
+			ErrSyntax,
 			`unable to parse %q as number (radix %v, fraction %v): unexpected end of input`,
-			src, radix, frac,
+			src, self.radix, frac,
 		)
+
+	case failOverflow:
+		return errorf(ErrRange, `unable to parse %q as number: overflow of int64`, src)
+
+	case failUnderflow:
+		return errorf(ErrRange, `unable to parse %q as number: underflow of int64`, src)
+
+	default:
+		panic(fmt.Errorf(`internal error: unknown parse failure code %v`, self.code))
 	}
-	return num, nil
+}
+
+/*
+Parses the longest numeric prefix of the input, returning the number and the
+amount of consumed bytes. The rest of the input is ignored, which allows
+building parsers for unit-suffixed values such as "12.50USD" or "3.5kg". Similar
+to `strconv.QuotedPrefix`. Shortcut for `ParseOpt.ParsePrefix`.
+
+The prefix is determined syntactically: an optional sign followed by digits,
+optionally followed by a point and more digits. It's then parsed like with
+`Parse`, so excess precision or overflow produce errors rather than a shorter
+prefix. A point not followed by a digit, like in "12.USD", isn't included.
+*/
+func ParsePrefix(src string, frac uint, radix uint) (num int64, n int, err error) {
+	return ParseOpt{Frac: frac, Radix: radix}.ParsePrefix(src)
+}
+
+/*
+Same as `ParsePrefix` but uses the options. The prefix follows the syntax
+enabled by the options. For example, with `Underscore`, "1_000_" consumes
+"1_000", and with `Lenient`, "12.USD" consumes "12.".
+*/
+func (self ParseOpt) ParsePrefix(src string) (num int64, n int, err error) {
+	n = self.prefixLen(src)
+	if n == 0 {
+		_, err = self.Parse(src)
+		if err == nil {
+			err = errorf(ErrSyntax, `unable to parse %q as number: no numeric prefix`, src)
+		}
+		return 0, 0, err
+	}
+
+	num, err = self.Parse(src[:n])
+	if err != nil {
+		return 0, 0, err
+	}
+	return num, n, nil
+}
+
+func (self ParseOpt) prefixLen(src string) int {
+	ind := 0
+	if self.Lenient {
+		ind += spacePrefixLen(src)
+	}
+
+	if ind < len(src) && (src[ind] == '+' || src[ind] == '-') {
+		ind++
+		if self.Lenient {
+			ind += spacePrefixLen(src[ind:])
+		}
+	}
+
+	if self.Prefix && ind+1 < len(src) && src[ind] == '0' {
+		pref := prefixRadix(src[ind+1])
+		if pref != 0 {
+			size := self.bodyLen(src[ind+2:], pref, true)
+			if size > 0 {
+				return ind + 2 + size
+			}
+		}
+	}
+
+	size := self.bodyLen(src[ind:], self.Radix, false)
+	if size > 0 {
+		return ind + size
+	}
+	return 0
+}
+
+func (self ParseOpt) bodyLen(src string, radix uint, prefixed bool) int {
+	mant := self.digitsLen(src, radix, prefixed)
+	ind := mant
+
+	if ind < len(src) && src[ind] == '.' && (mant > 0 || self.Lenient) {
+		size := self.digitsLen(src[ind+1:], radix, false)
+		if size > 0 {
+			ind += 1 + size
+		} else if mant > 0 && self.Lenient {
+			ind++
+		}
+	}
+	return ind
+}
+
+func (self ParseOpt) digitsLen(src string, radix uint, prefixed bool) int {
+	ind := 0
+	for ind < len(src) {
+		if self.Underscore && src[ind] == '_' && (ind > 0 || prefixed) &&
+			ind+1 < len(src) && self.isDigit(src[ind+1], radix) {
+			ind += 2
+			continue
+		}
+		if self.isDigit(src[ind], radix) {
+			ind++
+			continue
+		}
+		break
+	}
+	return ind
+}
+
+func (self ParseOpt) isDigit(char byte, radix uint) bool {
+	digit := self.digit(char)
+	return digit != unDigit && uint(digit) < radix
+}
+
+func (self ParseOpt) digit(char byte) byte {
+	if self.Alphabet != nil {
+		return self.Alphabet.values[char]
+	}
+	return toDigit(char, self.Case)
+}
+
+/*
+Parses a number with an optional Go-style radix prefix such as "0x", defaulting
+to radix 10. Similar to `strconv.ParseInt` with base 0. Shortcut for
+`ParseOpt{Frac: frac, Radix: 10, Prefix: true}.Parse(src)`. Examples:
+
+	"-12.5"    <- frac 2 -> -12_50
+	"-0x1.8"   <- frac 1 -> -0x1_8
+	"0b101.01" <- frac 2 -> 0b101_01
+*/
+func ParseAuto(src string, frac uint) (int64, error) {
+	return ParseOpt{Frac: frac, Radix: 10, Prefix: true}.Parse(src)
+}
+
+// Same as `ParseAuto` but takes a byte slice.
+func UnmarshalAuto(src []byte, frac uint) (int64, error) {
+	return ParseAuto(bytesToMutableString(src), frac)
 }
 
 // Shortcut for `UnmarshalBin(src, frac, 2)`.
@@ -162,7 +667,7 @@ func UnmarshalHex(src []byte, frac uint) (int64, error) {
 
 // Same as `Parse` but takes a byte slice.
 func Unmarshal(src []byte, frac uint, radix uint) (int64, error) {
-	return Parse(bytesToMutableString(src), frac, radix)
+	return ParseOpt{Frac: frac, Radix: radix}.Unmarshal(src)
 }
 
 // Shortcut for `Format(num, frac, 2)`.
@@ -223,28 +728,187 @@ returning the resulting union. When there's an error, the buffer is returned
 as-is with no hidden modifications.
 */
 func Append(buf []byte, num int64, frac uint, radix uint) ([]byte, error) {
-	if !(radix >= radixMin && radix <= radixMax) {
-		return buf, fmt.Errorf(`unable to format %v: unsupported radix %v`, num, radix)
+	// Invalid inputs are reported by the options.
+	if !(radix >= radixMin && radix <= radixMax && frac <= fracMax) {
+		return FormatOpt{Frac: frac, Radix: radix}.Append(buf, num)
 	}
+	return appendPlain(buf, num, frac, radix, digits), nil
+}
+
+/*
+Describes how to render the sign of a formatted number. The zero value
+`SignNeg` matches the default behavior of `Append`.
+*/
+type Sign byte
 
-	const bits = unsafe.Sizeof(num) * 8
-	if frac > uint(bits) {
-		return buf, fmt.Errorf(`unable to format %v: fractional precision %v exceeds limit %v`, num, frac, bits)
+const (
+	// Only negative numbers are prefixed with "-".
+	SignNeg Sign = iota
+
+	// Like the "+" flag in `fmt`: non-negative numbers are prefixed with "+".
+	SignPlus
+
+	// Like the " " flag in `fmt`: non-negative numbers are prefixed with " ".
+	SignSpace
+)
+
+/*
+Formatting options. `Append` and `Format` are shortcuts for a `FormatOpt` with
+only `Frac` and `Radix` set. Other fields are optional; their zero values match
+the default behavior.
+
+`Sign` controls the rendering of the sign; see `Sign`.
+
+When `Zero` is non-empty, it's used verbatim as the representation of the
+number 0, replacing the digits and the sign. For example, "0" can be used to
+avoid "+0" when `Sign` is `SignPlus`, and "±0" can be used to mark zero
+explicitly. Note that the output never contains "-0", because the input is an
+integer.
+
+`MinFrac` is the minimum amount of fractional digits. By default, trailing
+zeros are omitted; with `MinFrac = 2`, the number 12300 at `Frac = 2` is
+formatted as "123.00" rather than "123". `MinFrac` may exceed `Frac`, in which
+case the number is padded with zeros. Values are never truncated or rounded.
+
+When `Upper` is set, digits above 9 are written in uppercase, for example
+"7FFF.8" rather than "7fff.8" in radix 16.
+
+When `Alphabet` is set, digits are encoded with the given alphabet rather than
+the default "0-9a-z", and the radix may be as large as the alphabet. In this
+case, `Upper` is ignored.
+
+When `Prefix` is set, the number is prefixed with "0b", "0o" or "0x" for radix
+2, 8 or 16 respectively, after the sign. Uppercase "0X" is used when `Upper` is
+set. Other radixes have no prefix. See `ParseAuto` for the inverse.
+
+`Width` is the minimum width of the output, measured in characters. Shorter
+output is padded with spaces on the left, or on the right when `Left` is set.
+When `ZeroPad` is set and `Left` is not, the output is padded with zeros after
+the sign, like with the "0" flag in `fmt`.
+
+`Repeat` is used only by `FormatOpt.AppendRat`: when set, a repeating
+expansion that fits into `Frac` digits is rendered with the repeating digits in
+parentheses, for example "0.(3)" for 1/3.
+*/
+type FormatOpt struct {
+	Frac     uint
+	Radix    uint
+	Sign     Sign
+	Zero     string
+	MinFrac  uint
+	Upper    bool
+	Alphabet *Alphabet
+	Prefix   bool
+	Width    uint
+	Left     bool
+	ZeroPad  bool
+	Repeat   bool
+}
+
+// Same as `Format` but uses the options.
+func (self FormatOpt) Format(num int64) (string, error) {
+	buf, err := self.Append(nil, num)
+	return bytesToMutableString(buf), err
+}
+
+// Same as `Append` but uses the options.
+func (self FormatOpt) Append(buf []byte, num int64) ([]byte, error) {
+	err := self.Validate()
+	if err != nil {
+		return buf, fmt.Errorf(`unable to format %v: %w`, num, err)
 	}
+	return self.append(buf, num), nil
+}
 
-	var local [int(bits) + len(`-0.`)]byte
+/*
+Returns an error if the options are unsupported, such as an invalid radix, and
+nil otherwise. Formatting methods perform the same check, so this is useful
+only for reporting invalid options ahead of time.
+*/
+func (self FormatOpt) Validate() error {
+	if !(self.Radix >= radixMin && self.Radix <= self.Alphabet.radixMax()) {
+		return errorf(ErrRadix, `unsupported radix %v`, self.Radix)
+	}
+	if self.Frac > fracMax {
+		return fmt.Errorf(`fractional precision %v exceeds limit %v`, self.Frac, fracMax)
+	}
+	if !(self.Sign <= SignSpace) {
+		return fmt.Errorf(`unsupported sign mode %v`, self.Sign)
+	}
+	return nil
+}
+
+// Must be called after `FormatOpt.Validate`.
+func (self FormatOpt) append(buf []byte, num int64) []byte {
+	if self.plain() {
+		return appendPlain(buf, num, self.Frac, self.Radix, self.digits())
+	}
+
+	frac, radix := self.Frac, self.Radix
+
+	if num == 0 && self.Zero != `` {
+		return self.appendZero(buf)
+	}
+
+	var local [int(fracMax) + len(`0.`)]byte
 	ind := len(local)
 
-	var neg bool
-	var unum uint64
-	if num < 0 {
-		neg = true
-		unum = uint64(-num)
-	} else {
-		unum = uint64(num)
+	table := self.digits()
+	rad := uint64(radix)
+	whole, part := split(magnitude(num), frac, radix)
+	trailing := true
+	var digit uint64
+
+	for frac > 0 {
+		frac--
+		part, digit = pop(part, rad)
+
+		if digit == 0 && trailing && frac >= self.MinFrac {
+			continue
+		}
+		trailing = false
+
+		ind--
+		local[ind] = table[digit]
+
+		if frac == 0 {
+			ind--
+			local[ind] = '.'
+		}
 	}
 
+	for whole >= rad {
+		whole, digit = pop(whole, rad)
+		ind--
+		local[ind] = table[digit]
+	}
+
+	ind--
+	local[ind] = table[whole]
+
+	// Without fractional digits, zeros required by `MinFrac` need a point.
+	return self.appendBody(buf, num < 0, local[ind:], self.zeros(), self.Frac == 0)
+}
+
+/*
+True if the options affect only the digits, which allows the faster
+`appendPlain`.
+*/
+func (self FormatOpt) plain() bool {
+	return self.Sign == SignNeg && self.Zero == `` && self.MinFrac == 0 &&
+		!self.Prefix && self.Width == 0
+}
+
+/*
+Appends the digits of the number with only the sign and the point, using the
+given digit table. Must be called with a valid radix and precision.
+*/
+func appendPlain(buf []byte, num int64, frac uint, radix uint, table string) []byte {
+	var local [int(fracMax) + len(`-0.`)]byte
+	ind := len(local)
+
 	rad := uint64(radix)
+	unum := magnitude(num)
 	trailing := true
 	var digit uint64
 
@@ -258,7 +922,7 @@ func Append(buf []byte, num int64, frac uint, radix uint) ([]byte, error) {
 		trailing = false
 
 		ind--
-		local[ind] = digits[digit]
+		local[ind] = table[digit]
 
 		if frac == 0 {
 			ind--
@@ -269,54 +933,254 @@ func Append(buf []byte, num int64, frac uint, radix uint) ([]byte, error) {
 	for unum >= rad {
 		unum, digit = pop(unum, rad)
 		ind--
-		local[ind] = digits[digit]
+		local[ind] = table[digit]
 	}
 
 	ind--
-	local[ind] = digits[unum]
+	local[ind] = table[unum]
 
-	if neg {
+	if num < 0 {
 		ind--
 		local[ind] = '-'
 	}
 
-	return append(buf, local[ind:]...), nil
+	return append(buf, local[ind:]...)
+}
+
+func (self FormatOpt) appendZero(buf []byte) []byte {
+	pad := self.padding(uint(utf8.RuneCountInString(self.Zero)))
+	if !self.Left {
+		buf = appendRepeat(buf, ' ', pad)
+	}
+	buf = append(buf, self.Zero...)
+	if self.Left {
+		buf = appendRepeat(buf, ' ', pad)
+	}
+	return buf
+}
+
+/*
+Writes the sign, the prefix and the digits with padding. The digits are
+followed by the given amount of fractional zeros, preceded by a point when
+`point` is set.
+*/
+func (self FormatOpt) appendBody(buf []byte, neg bool, body []byte, zeros uint, point bool) []byte {
+	var sign byte
+	if neg {
+		sign = '-'
+	} else if self.Sign == SignPlus {
+		sign = '+'
+	} else if self.Sign == SignSpace {
+		sign = ' '
+	}
+
+	var prefix string
+	if self.Prefix {
+		prefix = radixPrefix(self.Radix, self.Upper)
+	}
+
+	point = point && zeros > 0
+	size := uint(len(body)) + uint(len(prefix)) + zeros
+	if sign != 0 {
+		size++
+	}
+	if point {
+		size++
+	}
+
+	pad := self.padding(size)
+	if !self.Left && !self.ZeroPad {
+		buf = appendRepeat(buf, ' ', pad)
+	}
+	if sign != 0 {
+		buf = append(buf, sign)
+	}
+	buf = append(buf, prefix...)
+	if !self.Left && self.ZeroPad {
+		buf = appendRepeat(buf, '0', pad)
+	}
+
+	buf = append(buf, body...)
+	if point {
+		buf = append(buf, '.')
+	}
+	buf = appendRepeat(buf, '0', zeros)
+
+	if self.Left {
+		buf = appendRepeat(buf, ' ', pad)
+	}
+	return buf
+}
+
+func (self FormatOpt) digits() string {
+	if self.Alphabet != nil {
+		return self.Alphabet.digits
+	}
+	if self.Upper {
+		return digitsUpper
+	}
+	return digits
+}
+
+// Amount of zeros after the fractional digits, required by `MinFrac`.
+func (self FormatOpt) zeros() uint {
+	if self.MinFrac > self.Frac {
+		return self.MinFrac - self.Frac
+	}
+	return 0
+}
+
+func (self FormatOpt) padding(size uint) uint {
+	if self.Width > size {
+		return self.Width - size
+	}
+	return 0
+}
+
+/*
+A number with its fractional precision, for use with the `fmt` package.
+Implements `fmt.Formatter`, routing all formatting through `FormatOpt.Append`.
+Supported verbs and their radixes:
+
+	%v %s %d %f -> 10
+	%x %X       -> 16 (%X uses uppercase digits)
+	%o          -> 8
+	%b          -> 2
+
+The precision, such as in "%.2f", is used as `FormatOpt.MinFrac`, and the
+width is used as `FormatOpt.Width`. The flags "+", " ", "-" and "0" have the
+same meaning as for integers. The flag "#" adds a radix prefix such as "0x".
+Example:
+
+	fmt.Sprintf(`%10.2f`, frac.Fixed{12345, 3}) == `    12.345`
+	fmt.Sprintf(`%+.2f`, frac.Fixed{12300, 3})  == `+12.30`
+*/
+type Fixed struct {
+	Num  int64
+	Frac uint
+}
+
+// Implement `fmt.Stringer`. Same as `FormatDec` but without the error.
+func (self Fixed) String() string { return fmt.Sprint(self) }
+
+// Implement `fmt.Formatter`.
+func (self Fixed) Format(out fmt.State, verb rune) {
+	opt := FormatOpt{Frac: self.Frac, Radix: verbRadix(verb)}
+	if opt.Radix == 0 {
+		fmt.Fprintf(out, `%%!%c(%T=%v/%v)`, verb, self, self.Num, self.Frac)
+		return
+	}
+
+	opt = opt.FromState(out)
+	opt.Upper = verb == 'X'
+
+	var local [128]byte
+	buf, err := opt.Append(local[:0], self.Num)
+	if err != nil {
+		fmt.Fprintf(out, `%%!%c(%v)`, verb, err)
+		return
+	}
+	_, _ = out.Write(buf)
+}
+
+/*
+Returns a copy of the options with `Sign`, `MinFrac`, `Width`, `Prefix`, `Left`
+and `ZeroPad` set from the flags, width and precision in the `fmt.State`, as
+described for `Fixed`. Useful for implementing `fmt.Formatter` on other types.
+Doesn't depend on the verb; `Radix` and `Upper` are left as-is.
+*/
+func (self FormatOpt) FromState(out fmt.State) FormatOpt {
+	if out.Flag('+') {
+		self.Sign = SignPlus
+	} else if out.Flag(' ') {
+		self.Sign = SignSpace
+	}
+
+	if prec, ok := out.Precision(); ok && prec > 0 {
+		self.MinFrac = uint(prec)
+	}
+	if width, ok := out.Width(); ok && width > 0 {
+		self.Width = uint(width)
+	}
+	self.Prefix = out.Flag('#')
+	self.Left = out.Flag('-')
+	self.ZeroPad = out.Flag('0')
+	return self
+}
+
+func verbRadix(verb rune) uint {
+	switch verb {
+	case 'v', 's', 'd', 'f':
+		return 10
+	case 'x', 'X':
+		return 16
+	case 'o':
+		return 8
+	case 'b':
+		return 2
+	default:
+		return 0
+	}
 }
 
 const (
-	digits   = `0123456789abcdefghijklmnopqrstuvwxyz`
-	radixMin = uint(2)
-	radixMax = uint(len(digits))
+	digits           = `0123456789abcdefghijklmnopqrstuvwxyz`
+	digitsUpper      = `0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ`
+	radixMin         = uint(2)
+	radixMax         = uint(len(digits))
+	radixMaxAlphabet = uint(64)
+	fracMax          = uint(unsafe.Sizeof(int64(0)) * 8)
 )
 
-func inc(src string, prev int64, radix uint, sign int64, digit byte) (int64, error) {
+/*
+Appends a digit to the number. Multiplication can wrap around to a value of
+either sign, so the number is checked against the limits before multiplying;
+after that, only the addition can wrap around, which flips the sign.
+*/
+func inc(prev int64, radix uint, sign int64, digit byte) (int64, parseCode) {
+	limit := &mulLimits[radix]
+	if prev > limit.max {
+		return 0, failOverflow
+	}
+	if prev < limit.min {
+		return 0, failUnderflow
+	}
+
 	next := prev*int64(radix) + sign*int64(digit)
 	if prev > 0 && next < prev {
-		return 0, fmt.Errorf(`unable to parse %q as number: overflow of %T`, src, next)
+		return 0, failOverflow
 	}
 	if prev < 0 && next > prev {
-		return 0, fmt.Errorf(`unable to parse %q as number: underflow of %T`, src, next)
+		return 0, failUnderflow
 	}
-	return next, nil
+	return next, failNone
 }
 
 const unDigit byte = 255
 
-func toDigit(char byte) byte {
+// Result of `toDigit` for each character and case, for `plainParser`.
+var caseDigits = func() (out [CaseUpper + 1][256]byte) {
+	for letterCase := range out {
+		for char := range out[letterCase] {
+			out[letterCase][char] = toDigit(byte(char), Case(letterCase))
+		}
+	}
+	return
+}()
+
+func toDigit(char byte, letterCase Case) byte {
 	if char >= '0' && char <= '9' {
 		return char - '0'
 	}
-	char = lower(char)
-	if char >= 'a' && char <= 'z' {
+	if letterCase != CaseUpper && char >= 'a' && char <= 'z' {
 		return char - 'a' + 10
 	}
+	if letterCase != CaseLower && char >= 'A' && char <= 'Z' {
+		return char - 'A' + 10
+	}
 	return unDigit
 }
 
-func lower(char byte) byte {
-	return char | ('a' - 'A')
-}
-
 func runeAt(str string, index int) rune {
 	for ind, char := range str {
 		if ind == index {
@@ -326,6 +1190,42 @@ func runeAt(str string, index int) rune {
 	panic(fmt.Errorf(`failed to get rune from %q at %v`, str, index))
 }
 
+func prefixRadix(char byte) uint {
+	switch char {
+	case 'b', 'B':
+		return 2
+	case 'o', 'O':
+		return 8
+	case 'x', 'X':
+		return 16
+	default:
+		return 0
+	}
+}
+
+func radixPrefix(radix uint, upper bool) string {
+	switch radix {
+	case 2:
+		return `0b`
+	case 8:
+		return `0o`
+	case 16:
+		if upper {
+			return `0X`
+		}
+		return `0x`
+	default:
+		return ``
+	}
+}
+
+func appendRepeat(buf []byte, char byte, count uint) []byte {
+	for ; count > 0; count-- {
+		buf = append(buf, char)
+	}
+	return buf
+}
+
 func pop(num uint64, radix uint64) (uint64, uint64) {
 	quot := num / radix
 	digit := num - quot*radix
@@ -1,6 +1,7 @@
 package frac
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -324,6 +325,342 @@ func TestFormatHex(*testing.T) {
 	testFormatErrHex(-1, 65, `exceeds limit`)
 }
 
+func TestFormatSign(*testing.T) {
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10}, 12_50, `12.5`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10}, -12_50, `-12.5`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10}, 0, `0`)
+
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Sign: SignPlus}, 12_50, `+12.5`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Sign: SignPlus}, -12_50, `-12.5`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Sign: SignPlus}, 0, `+0`)
+	testFormatOpt(FormatOpt{Frac: 0, Radix: 16, Sign: SignPlus}, math.MaxInt64, `+7fffffffffffffff`)
+	testFormatOpt(FormatOpt{Frac: 64, Radix: 10, Sign: SignPlus}, math.MaxInt64, `+0.0000000000000000000000000000000000000000000009223372036854775807`)
+	testFormatOpt(FormatOpt{Frac: 64, Radix: 10, Sign: SignPlus}, math.MinInt64, `-0.0000000000000000000000000000000000000000000009223372036854775808`)
+
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Sign: SignSpace}, 12_50, ` 12.5`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Sign: SignSpace}, -12_50, `-12.5`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Sign: SignSpace}, 0, ` 0`)
+
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Zero: `0`, Sign: SignPlus}, 0, `0`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Zero: `±0`, Sign: SignPlus}, 0, `±0`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Zero: `±0`, Sign: SignPlus}, 1, `+0.01`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Zero: `±0`, Sign: SignPlus}, -1, `-0.01`)
+
+	testFormatOptErr(FormatOpt{Frac: 2, Radix: 10, Sign: SignSpace + 1}, 0, `unsupported sign mode`)
+	testFormatOptErr(FormatOpt{Frac: 2, Radix: 37, Zero: `0`}, 0, `unsupported radix`)
+}
+
+func TestFormatPadding(*testing.T) {
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, MinFrac: 2}, 123_00, `123.00`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, MinFrac: 2}, 123_40, `123.40`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, MinFrac: 1}, 123_00, `123.0`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, MinFrac: 1}, 123_45, `123.45`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, MinFrac: 4}, -123_45, `-123.4500`)
+	testFormatOpt(FormatOpt{Frac: 0, Radix: 10, MinFrac: 2}, 123, `123.00`)
+	testFormatOpt(FormatOpt{Frac: 3, Radix: 10, MinFrac: 2}, 12_345, `12.345`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, MinFrac: 2, Sign: SignPlus}, 12_50, `+12.50`)
+
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Width: 8}, 12_50, `    12.5`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Width: 8, Left: true}, 12_50, `12.5    `)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Width: 8, ZeroPad: true}, -12_50, `-00012.5`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Width: 8, ZeroPad: true, Left: true}, -12_50, `-12.5   `)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Width: 2}, -12_50, `-12.5`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Width: 4, Zero: `±0`}, 0, `  ±0`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Width: 4, Zero: `±0`, Left: true}, 0, `±0  `)
+}
+
+// `Append` skips the option handling; its output must match the general path.
+func TestFormatPlain(*testing.T) {
+	nums := []int64{0, 1, -1, 12_345, -12_300, math.MaxInt64, math.MinInt64}
+
+	for radix := radixMin; radix <= radixMax; radix++ {
+		for frac := uint(0); frac <= fracMax; frac++ {
+			for _, num := range nums {
+				// A width of 1 never adds padding, but disables the fast path.
+				exp, err := FormatOpt{Frac: frac, Radix: radix, Width: 1}.Format(num)
+				if err != nil {
+					panic(err)
+				}
+				testFormat(num, frac, radix, exp)
+			}
+		}
+	}
+}
+
+func TestFormatOptValidate(*testing.T) {
+	testEqual(FormatOpt{Frac: 2, Radix: 10}.Validate(), nil)
+	testEqual(FormatOpt{Frac: 0, Radix: 62, Alphabet: AlphabetBase62}.Validate(), nil)
+
+	err := FormatOpt{Frac: 2, Radix: 37}.Validate()
+	if !errors.Is(err, ErrRadix) || err.Error() != `unsupported radix 37` {
+		panic(fmt.Errorf(`unexpected Validate error: %v`, err))
+	}
+
+	err = FormatOpt{Frac: 65, Radix: 10}.Validate()
+	if err == nil || err.Error() != `fractional precision 65 exceeds limit 64` {
+		panic(fmt.Errorf(`unexpected Validate error: %v`, err))
+	}
+}
+
+func TestFixedFormat(*testing.T) {
+	testSprintf(`%v`, Fixed{123_45, 2}, `123.45`)
+	testSprintf(`%s`, Fixed{-123_45, 2}, `-123.45`)
+	testSprintf(`%d`, Fixed{123_00, 2}, `123`)
+	testSprintf(`%.2f`, Fixed{123_00, 2}, `123.00`)
+	testSprintf(`%.2f`, Fixed{12_345, 3}, `12.345`)
+	testSprintf(`%10.2f`, Fixed{-123_40, 2}, `   -123.40`)
+	testSprintf(`%-10.2f|`, Fixed{123_40, 2}, `123.40    |`)
+	testSprintf(`%010.2f`, Fixed{-123_40, 2}, `-000123.40`)
+	testSprintf(`%+v`, Fixed{123_45, 2}, `+123.45`)
+	testSprintf(`% v`, Fixed{123_45, 2}, ` 123.45`)
+	testSprintf(`%x`, Fixed{0xff_8, 1}, `ff.8`)
+	testSprintf(`%o`, Fixed{0o17_4, 1}, `17.4`)
+	testSprintf(`%b`, Fixed{0b101_01, 2}, `101.01`)
+	testSprintf(`%q`, Fixed{123_45, 2}, `%!q(frac.Fixed=12345/2)`)
+	testSprintf(`%v`, Fixed{1, 65}, `%!v(unable to format 1: fractional precision 65 exceeds limit 64)`)
+
+	if act := (Fixed{123_45, 2}).String(); act != `123.45` {
+		panic(fmt.Errorf(`expected String to return %q, got %q`, `123.45`, act))
+	}
+}
+
+func TestFormatUpper(*testing.T) {
+	testFormatOpt(FormatOpt{Frac: 1, Radix: 16, Upper: true}, 0x7fff_8, `7FFF.8`)
+	testFormatOpt(FormatOpt{Frac: 0, Radix: 36, Upper: true}, 35, `Z`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Upper: true}, -123_45, `-123.45`)
+	testSprintf(`%X`, Fixed{0x7fff_8, 1}, `7FFF.8`)
+}
+
+func TestParseCase(*testing.T) {
+	testParseOpt(ParseOpt{Frac: 1, Radix: 16}, `7fff.8`, 0x7fff_8)
+	testParseOpt(ParseOpt{Frac: 1, Radix: 16}, `7FfF.8`, 0x7fff_8)
+	testParseOpt(ParseOpt{Frac: 1, Radix: 16, Case: CaseLower}, `7fff.8`, 0x7fff_8)
+	testParseOpt(ParseOpt{Frac: 1, Radix: 16, Case: CaseUpper}, `7FFF.8`, 0x7fff_8)
+	testParseOpt(ParseOpt{Frac: 1, Radix: 10, Case: CaseUpper}, `-12.3`, -12_3)
+
+	testParseOptErr(ParseOpt{Frac: 1, Radix: 16, Case: CaseLower}, `7FFF.8`, `non-digit character 'F'`)
+	testParseOptErr(ParseOpt{Frac: 1, Radix: 16, Case: CaseUpper}, `7fff.8`, `non-digit character 'f'`)
+	testParseOptErr(ParseOpt{Frac: 1, Radix: 16, Case: CaseUpper + 1}, `7`, `unsupported case mode`)
+}
+
+func TestParseAuto(*testing.T) {
+	testParseAuto(`0`, 2, 0)
+	testParseAuto(`0.5`, 2, 50)
+	testParseAuto(`010`, 0, 10)
+	testParseAuto(`-12.5`, 2, -12_50)
+	testParseAuto(`0x1.8`, 1, 0x1_8)
+	testParseAuto(`-0x1.8`, 1, -0x1_8)
+	testParseAuto(`+0XfF`, 0, 0xff)
+	testParseAuto(`0o17.4`, 1, 0o17_4)
+	testParseAuto(`0O17`, 1, 0o17_0)
+	testParseAuto(`0b101.01`, 2, 0b101_01)
+	testParseAuto(`-0B101.01`, 3, -0b101_010)
+	testParseAuto(`-0x8000000000000000`, 0, math.MinInt64)
+
+	testParseErrAuto(`0x`, `unexpected end of input`)
+	testParseErrAuto(`-0b`, `unexpected end of input`)
+	testParseErrAuto(`0x.8`, `non-digit character '.'`)
+	testParseErrAuto(`0x0x1`, `non-digit character 'x'`)
+	testParseErrAuto(`0b102`, `non-digit character '2'`)
+	testParseErrAuto(`0o8`, `non-digit character '8'`)
+	testParseErrAuto(`ff`, `non-digit character 'f'`)
+	testParseErrAuto(`x1`, `non-digit character 'x'`)
+	testParseErrAuto(`1x1`, `non-digit character 'x'`)
+
+	testParseOpt(ParseOpt{Radix: 16, Prefix: true}, `ff`, 0xff)
+	testParseOpt(ParseOpt{Radix: 16, Prefix: true}, `0b11`, 0b11)
+	testParseOpt(ParseOpt{Radix: 16}, `0b11`, 0xb11)
+	testParseOptErr(ParseOpt{Radix: 16}, `0x11`, `non-digit character 'x'`)
+
+	alpha, err := NewAlphabet(`01234567`, false, nil)
+	if err != nil {
+		panic(err)
+	}
+	testParseOpt(ParseOpt{Radix: 8, Prefix: true, Alphabet: alpha}, `0b11`, 0b11)
+	testParseOptErr(ParseOpt{Radix: 8, Prefix: true, Alphabet: alpha}, `0x11`, `unsupported radix 16`)
+}
+
+func TestFormatPrefix(*testing.T) {
+	testFormatOpt(FormatOpt{Frac: 1, Radix: 16, Prefix: true}, 0x1_8, `0x1.8`)
+	testFormatOpt(FormatOpt{Frac: 1, Radix: 16, Prefix: true, Upper: true}, -0xf_8, `-0XF.8`)
+	testFormatOpt(FormatOpt{Frac: 1, Radix: 8, Prefix: true}, 0o17_4, `0o17.4`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 2, Prefix: true, Sign: SignPlus}, 0b101_01, `+0b101.01`)
+	testFormatOpt(FormatOpt{Frac: 2, Radix: 10, Prefix: true}, 12_34, `12.34`)
+	testFormatOpt(FormatOpt{Frac: 1, Radix: 16, Prefix: true, Width: 8, ZeroPad: true}, -0x1_8, `-0x001.8`)
+	testFormatOpt(FormatOpt{Frac: 1, Radix: 16, Prefix: true, Width: 8}, -0x1_8, `  -0x1.8`)
+	testSprintf(`%#x`, Fixed{0x1_8, 1}, `0x1.8`)
+	testSprintf(`%#X`, Fixed{0xf_8, 1}, `0XF.8`)
+	testSprintf(`%#b`, Fixed{0b101_01, 2}, `0b101.01`)
+	testSprintf(`%#v`, Fixed{123_45, 2}, `123.45`)
+
+	for _, radix := range []uint{2, 8, 10, 16} {
+		for _, num := range []int64{0, 1, -1, 0x1_8, -0x1_8, math.MaxInt64, math.MinInt64} {
+			src, err := FormatOpt{Frac: 4, Radix: radix, Prefix: true}.Format(num)
+			if err != nil {
+				panic(err)
+			}
+			testParseAuto(src, 4, num)
+		}
+	}
+}
+
+func testParseAuto(src string, frac uint, exp int64) {
+	testParseOpt(ParseOpt{Frac: frac, Radix: 10, Prefix: true}, src, exp)
+
+	act, err := ParseAuto(src, frac)
+	if err != nil || act != exp {
+		panic(fmt.Errorf(`expected ParseAuto(%q, %v) to return %v, got %v, %v`, src, frac, exp, act, err))
+	}
+}
+
+func testParseErrAuto(src string, msg string) {
+	testParseOptErr(ParseOpt{Frac: 2, Radix: 10, Prefix: true}, src, msg)
+}
+
+func TestParseUnderscore(*testing.T) {
+	opt := ParseOpt{Frac: 2, Radix: 10, Underscore: true}
+	testParseOpt(opt, `1_000_000.00`, 1_000_000_00)
+	testParseOpt(opt, `-1_000_000.0_1`, -1_000_000_01)
+	testParseOpt(opt, `+1_2.3_0_0`, 12_30)
+	testParseOpt(opt, `123_45`, 123_45_00)
+	testParseOpt(opt, `0`, 0)
+
+	testParseOptErr(opt, `_1`, `"_" must separate successive digits`)
+	testParseOptErr(opt, `-_1`, `"_" must separate successive digits`)
+	testParseOptErr(opt, `1_`, `"_" must separate successive digits`)
+	testParseOptErr(opt, `1.0_`, `"_" must separate successive digits`)
+	testParseOptErr(opt, `1__0`, `"_" must separate successive digits`)
+	testParseOptErr(opt, `1_.0`, `"_" must separate successive digits`)
+	testParseOptErr(opt, `1._0`, `"_" must separate successive digits`)
+	testParseOptErr(opt, `_`, `"_" must separate successive digits`)
+	testParseOptErr(opt, `1.00_1`, `exponent exceeds`)
+	testParseOptErr(ParseOpt{Frac: 2, Radix: 10}, `1_0`, `non-digit character '_'`)
+
+	opt = ParseOpt{Frac: 1, Radix: 10, Underscore: true, Prefix: true}
+	testParseOpt(opt, `0x_ff.8`, 0xff_8)
+	testParseOpt(opt, `-0b_1_0`, -0b10_0)
+	testParseOptErr(opt, `0x__ff`, `"_" must separate successive digits`)
+	testParseOptErr(opt, `0x_`, `unexpected end of input`)
+	testParseOptErr(opt, `0_x1`, `non-digit character 'x'`)
+	testParseOpt(opt, `0_1`, 1_0)
+}
+
+func TestParseLenient(*testing.T) {
+	opt := ParseOpt{Frac: 2, Radix: 10, Lenient: true}
+	testParseOpt(opt, ` 12`, 12_00)
+	testParseOpt(opt, "\t12.5\n", 12_50)
+	testParseOpt(opt, "\u00a012.5\u3000", 12_50)
+	testParseOpt(opt, `12.`, 12_00)
+	testParseOpt(opt, `-12.`, -12_00)
+	testParseOpt(opt, `.12`, 12)
+	testParseOpt(opt, `-.5`, -50)
+	testParseOpt(opt, `+.5`, 50)
+	testParseOpt(opt, `- 5`, -5_00)
+	testParseOpt(opt, " + \t5.25 ", 5_25)
+	testParseOpt(opt, "-\u00a0.5", -50)
+	testParseOpt(opt, `12`, 12_00)
+
+	testParseOptErr(opt, ``, `empty input`)
+	testParseOptErr(opt, ` `, `empty input`)
+	testParseOptErr(opt, `.`, `unexpected end of input`)
+	testParseOptErr(opt, `-.`, `unexpected end of input`)
+	testParseOptErr(opt, `- .`, `unexpected end of input`)
+	testParseOptErr(opt, `-`, `unexpected end of input`)
+	testParseOptErr(opt, `- `, `unexpected end of input`)
+	testParseOptErr(opt, `1 2`, `non-digit character ' '`)
+	testParseOptErr(opt, `12 .5`, `non-digit character ' '`)
+	testParseOptErr(opt, `12. 5`, `non-digit character ' '`)
+	testParseOptErr(opt, `12..`, `non-digit character '.'`)
+	testParseOptErr(opt, `..5`, `non-digit character '.'`)
+	testParseOptErr(opt, `- -5`, `non-digit character '-'`)
+	testParseOptErr(opt, `.125`, `exponent exceeds`)
+
+	testParseOpt(ParseOpt{Frac: 1, Radix: 10, Lenient: true, Prefix: true}, ` - 0x.8 `, -0x0_8)
+	testParseOpt(ParseOpt{Frac: 1, Radix: 10, Lenient: true, Prefix: true}, `0x1.`, 0x1_0)
+	testParseOptErr(ParseOpt{Frac: 1, Radix: 10, Lenient: true, Underscore: true}, `._5`, `"_" must separate successive digits`)
+	testParseOptErr(ParseOpt{Frac: 1, Radix: 10, Lenient: true, Underscore: true}, `5_.`, `"_" must separate successive digits`)
+}
+
+func TestParsePrefix(*testing.T) {
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `12.50USD`, 12_50, 5)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `3.5kg`, 3_50, 3)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `-3kg`, -3_00, 2)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `+3`, 3_00, 2)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `12.USD`, 12_00, 2)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `12..5`, 12_00, 2)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `1.2.3`, 1_20, 3)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `12 USD`, 12_00, 2)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `0x10`, 0, 1)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10}, `1_000`, 1_00, 1)
+	testParsePrefix(ParseOpt{Frac: 0, Radix: 16}, `ffg`, 0xff, 2)
+	testParsePrefix(ParseOpt{Frac: 0, Radix: 16, Case: CaseLower}, `ffF`, 0xff, 2)
+
+	testParsePrefixErr(ParseOpt{Frac: 2, Radix: 10}, ``, `empty input`)
+	testParsePrefixErr(ParseOpt{Frac: 2, Radix: 10}, `USD`, `non-digit character 'U'`)
+	testParsePrefixErr(ParseOpt{Frac: 2, Radix: 10}, `-USD`, `non-digit character 'U'`)
+	testParsePrefixErr(ParseOpt{Frac: 2, Radix: 10}, `.5kg`, `non-digit character '.'`)
+	testParsePrefixErr(ParseOpt{Frac: 2, Radix: 10}, `12.345kg`, `exponent exceeds`)
+	testParsePrefixErr(ParseOpt{Frac: 0, Radix: 10}, maxInt64+`0kg`, `overflow`)
+	testParsePrefixErr(ParseOpt{Frac: 0, Radix: 37}, `1`, `unsupported radix`)
+
+	testParsePrefix(ParseOpt{Frac: 1, Radix: 10, Prefix: true}, `0x1.8p`, 0x1_8, 5)
+	testParsePrefix(ParseOpt{Frac: 1, Radix: 10, Prefix: true}, `-0b1.1b`, -0b1_1, 6)
+	testParsePrefix(ParseOpt{Frac: 1, Radix: 10, Prefix: true}, `0xg`, 0, 1)
+	testParsePrefix(ParseOpt{Frac: 1, Radix: 10, Prefix: true, Underscore: true}, `0x_f_f_`, 0xff_0, 6)
+	testParsePrefix(ParseOpt{Frac: 1, Radix: 10, Prefix: true, Underscore: true}, `0x__f`, 0, 1)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10, Underscore: true}, `1_000_`, 1_000_00, 5)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10, Underscore: true}, `1__0`, 1_00, 1)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10, Underscore: true}, `1_0.2_5_x`, 10_25, 7)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10, Underscore: true}, `1._5`, 1_00, 1)
+	testParsePrefixErr(ParseOpt{Frac: 2, Radix: 10, Underscore: true}, `_1`, `"_" must separate successive digits`)
+
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10, Lenient: true}, ` - .5 kg`, -50, 5)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10, Lenient: true}, `12.USD`, 12_00, 3)
+	testParsePrefix(ParseOpt{Frac: 2, Radix: 10, Lenient: true}, `12..`, 12_00, 3)
+	testParsePrefix(ParseOpt{Frac: 1, Radix: 10, Lenient: true, Prefix: true}, `0x.8h`, 0x0_8, 4)
+	testParsePrefixErr(ParseOpt{Frac: 2, Radix: 10, Lenient: true}, ` . kg`, `non-digit character ' '`)
+
+	num, n, err := ParsePrefix(`12.50USD`, 2, 10)
+	if num != 12_50 || n != 5 || err != nil {
+		panic(fmt.Errorf(`unexpected ParsePrefix result: %v, %v, %v`, num, n, err))
+	}
+}
+
+func TestParseErrKind(*testing.T) {
+	testParseErrKind(ParseOpt{Frac: 2, Radix: 10}, ``, ErrSyntax)
+	testParseErrKind(ParseOpt{Frac: 2, Radix: 10}, `1.2.3`, ErrSyntax)
+	testParseErrKind(ParseOpt{Frac: 2, Radix: 10}, `1.`, ErrSyntax)
+	testParseErrKind(ParseOpt{Frac: 2, Radix: 10, Underscore: true}, `1__0`, ErrSyntax)
+	testParseErrKind(ParseOpt{Frac: 2, Radix: 10}, `1.255`, ErrPrecision)
+	testParseErrKind(ParseOpt{Frac: 0, Radix: 10}, maxInt64+`0`, ErrRange)
+	testParseErrKind(ParseOpt{Frac: 0, Radix: 10}, `-`+maxInt64+`0`, ErrRange)
+	testParseErrKind(ParseOpt{Frac: 18, Radix: 10}, `10`, ErrRange)
+	testParseErrKind(ParseOpt{Frac: 0, Radix: 37}, `1`, ErrRadix)
+	testParseErrKind(ParseOpt{Frac: 0, Radix: 10, Prefix: true, Alphabet: mustAlphabet(`0123456789`, false, nil)}, `0x1`, ErrRadix)
+
+	_, _, err := ParsePrefix(`USD`, 2, 10)
+	if !errors.Is(err, ErrSyntax) {
+		panic(fmt.Errorf(`expected ParsePrefix error to be ErrSyntax, got %v`, err))
+	}
+
+	_, err = NewDecoder(strings.NewReader(`1 2.555`), ParseOpt{Frac: 2, Radix: 10}).Decode(nil)
+	if !errors.Is(err, ErrPrecision) {
+		panic(fmt.Errorf(`expected Decoder error to be ErrPrecision, got %v`, err))
+	}
+}
+
+func testParseErrKind(opt ParseOpt, src string, kind error) {
+	_, err := opt.Parse(src)
+	if !errors.Is(err, kind) {
+		panic(fmt.Errorf(`expected error from parsing %q (%+v) to be %q, got %v`, src, opt, kind, err))
+	}
+	for _, other := range []error{ErrSyntax, ErrPrecision, ErrRange, ErrRadix} {
+		if other != kind && errors.Is(err, other) {
+			panic(fmt.Errorf(`expected error from parsing %q (%+v) not to be %q, got %v`, src, opt, other, err))
+		}
+	}
+}
+
 func testParseBin(src string, frac uint, exp int64) { testParse(src, frac, 2, exp) }
 func testParseDec(src string, frac uint, exp int64) { testParse(src, frac, 10, exp) }
 func testParseHex(src string, frac uint, exp int64) { testParse(src, frac, 16, exp) }
@@ -390,4 +727,74 @@ func testFormatErr(num int64, radix uint, frac uint, msg string) {
 	}
 }
 
+func testParseOpt(opt ParseOpt, src string, exp int64) {
+	act, err := opt.Parse(src)
+	if err != nil {
+		panic(fmt.Errorf(`failed to parse %q (%+v): %+v`, src, opt, err))
+	}
+	if exp != act {
+		panic(fmt.Errorf(`expected to parse %q (%+v) into %v, got %v`, src, opt, exp, act))
+	}
+}
+
+func testParseOptErr(opt ParseOpt, src string, msg string) {
+	res, err := opt.Parse(src)
+	if err == nil {
+		panic(fmt.Errorf(`expected parsing %q (%+v) to fail; instead got %v`, src, opt, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from parsing %q (%+v) to contain %q, got %q`, src, opt, msg, err))
+	}
+}
+
+func testParsePrefix(opt ParseOpt, src string, exp int64, expLen int) {
+	act, n, err := opt.ParsePrefix(src)
+	if err != nil {
+		panic(fmt.Errorf(`failed to parse prefix of %q (%+v): %+v`, src, opt, err))
+	}
+	if exp != act || expLen != n {
+		panic(fmt.Errorf(`expected to parse prefix of %q (%+v) into %v, %v; got %v, %v`, src, opt, exp, expLen, act, n))
+	}
+}
+
+func testParsePrefixErr(opt ParseOpt, src string, msg string) {
+	res, n, err := opt.ParsePrefix(src)
+	if err == nil {
+		panic(fmt.Errorf(`expected parsing prefix of %q (%+v) to fail; instead got %v, %v`, src, opt, res, n))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from parsing prefix of %q (%+v) to contain %q, got %q`, src, opt, msg, err))
+	}
+	if res != 0 || n != 0 {
+		panic(fmt.Errorf(`expected failed parsing of prefix of %q (%+v) to return zeros, got %v, %v`, src, opt, res, n))
+	}
+}
+
+func testFormatOpt(opt FormatOpt, num int64, exp string) {
+	act, err := opt.Format(num)
+	if err != nil {
+		panic(fmt.Errorf(`failed to format %v (%+v): %+v`, num, opt, err))
+	}
+	if exp != act {
+		panic(fmt.Errorf(`expected to format %v (%+v) into %q, got %q`, num, opt, exp, act))
+	}
+}
+
+func testFormatOptErr(opt FormatOpt, num int64, msg string) {
+	res, err := opt.Format(num)
+	if err == nil {
+		panic(fmt.Errorf(`expected formatting %v (%+v) to fail; instead got %q`, num, opt, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from formatting %v (%+v) to contain %q, got %q`, num, opt, msg, err))
+	}
+}
+
+func testSprintf(pattern string, val interface{}, exp string) {
+	act := fmt.Sprintf(pattern, val)
+	if exp != act {
+		panic(fmt.Errorf(`expected %q with %#v to produce %q, got %q`, pattern, val, exp, act))
+	}
+}
+
 func counter(count int) []struct{} { return make([]struct{}, count) }
@@ -0,0 +1,15 @@
+/*
+Reports unsafe float conversions of scaled integer types and mismatched
+fractional precision; see package `fraccheck`. Usage:
+
+	go install github.com/mitranim/frac/fraccheck/cmd/fraccheck@latest
+	go vet -vettool=$(which fraccheck) ./...
+*/
+package main
+
+import (
+	"github.com/mitranim/frac/fraccheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(fraccheck.Analyzer) }
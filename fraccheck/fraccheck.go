@@ -0,0 +1,284 @@
+/*
+Analyzer reporting unsafe conversions of scaled integers, for use with `go vet`
+via `-vettool` or with any driver for `golang.org/x/tools/go/analysis`.
+
+A scaled type is a defined integer type whose `MarshalText` or `UnmarshalText`
+method delegates to a function of `github.com/mitranim/frac`, such as
+`frac.AppendDec` or `frac.UnmarshalDec`. Scaled types are detected across
+packages. The analyzer reports:
+
+  - Conversions of scaled values to floats, such as `float64(cents)/100`.
+
+  - Conversions of non-constant floats to scaled types, such as
+    `Cents(price*100)`. Intermediate integer conversions, such as in
+    `Cents(int64(price*100))`, are looked through.
+
+  - Formatting calls such as `frac.AppendDec` in methods of a scaled type whose
+    fractional precision differs from the precision of the parsing calls such as
+    `frac.UnmarshalDec` in methods of the same type.
+*/
+package fraccheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// Import path of the analyzed library.
+const fracPath = `github.com/mitranim/frac`
+
+var Analyzer = &analysis.Analyzer{
+	Name:      `fraccheck`,
+	Doc:       `report float conversions of scaled integer types and mismatched fractional precision`,
+	Run:       run,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(scaledType)},
+}
+
+/*
+Fact attached to the type name of a scaled type. `Frac` is the fractional
+precision used for parsing, when it's a constant.
+*/
+type scaledType struct {
+	Frac  uint64
+	Known bool
+}
+
+func (*scaledType) AFact() {}
+
+func (self *scaledType) String() string {
+	if self.Known {
+		return fmt.Sprintf(`scaled(frac %v)`, self.Frac)
+	}
+	return `scaled`
+}
+
+// Call of a function from `github.com/mitranim/frac` in a method of a type.
+type fracCall struct {
+	call   *ast.CallExpr
+	fun    *types.Func
+	format bool
+	frac   uint64
+	known  bool
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	calls := map[*types.TypeName][]fracCall{}
+	scaled := map[*types.TypeName]bool{}
+
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(node ast.Node) {
+		decl := node.(*ast.FuncDecl)
+		if decl.Recv == nil || decl.Body == nil {
+			return
+		}
+
+		typ := recvTypeName(pass, decl)
+		if typ == nil || !isInteger(typ.Type()) {
+			return
+		}
+
+		marshal := decl.Name.Name == `MarshalText` || decl.Name.Name == `UnmarshalText`
+
+		ast.Inspect(decl.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			val, ok := newFracCall(pass, call)
+			if ok {
+				calls[typ] = append(calls[typ], val)
+				if marshal {
+					scaled[typ] = true
+				}
+			}
+			return true
+		})
+	})
+
+	for typ := range scaled {
+		fact := &scaledType{}
+		for _, val := range calls[typ] {
+			if !val.format && val.known {
+				fact.Frac, fact.Known = val.frac, true
+				break
+			}
+		}
+		pass.ExportObjectFact(typ, fact)
+		checkFrac(pass, typ, calls[typ])
+	}
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		checkConversion(pass, node.(*ast.CallExpr))
+	})
+	return nil, nil
+}
+
+// Reports formatting calls whose precision differs from the parsing precision.
+func checkFrac(pass *analysis.Pass, typ *types.TypeName, calls []fracCall) {
+	var parse *fracCall
+	for ind := range calls {
+		if !calls[ind].format && calls[ind].known {
+			parse = &calls[ind]
+			break
+		}
+	}
+	if parse == nil {
+		return
+	}
+
+	for _, val := range calls {
+		if val.format && val.known && val.frac != parse.frac {
+			pass.Reportf(
+				val.call.Pos(),
+				`frac.%v uses fraction %v, but frac.%v for %v uses fraction %v`,
+				val.fun.Name(), val.frac, parse.fun.Name(), typ.Name(), parse.frac,
+			)
+		}
+	}
+}
+
+func checkConversion(pass *analysis.Pass, call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	tv, ok := pass.TypesInfo.Types[call.Fun]
+	if !ok || !tv.IsType() {
+		return
+	}
+
+	dst := tv.Type
+	src := unwrapIntConversions(pass, call.Args[0])
+	srcTv, ok := pass.TypesInfo.Types[src]
+	if !ok {
+		return
+	}
+
+	if isFloat(dst) {
+		name := scaledName(pass, srcTv.Type)
+		if name != `` {
+			pass.Reportf(
+				call.Pos(),
+				`conversion of scaled type %v to %v loses precision; use frac.Format or frac.Parts`,
+				name, types.TypeString(dst, types.RelativeTo(pass.Pkg)),
+			)
+		}
+		return
+	}
+
+	name := scaledName(pass, dst)
+	if name != `` && srcTv.Value == nil && isFloat(srcTv.Type) {
+		pass.Reportf(
+			call.Pos(),
+			`conversion of float to scaled type %v may round incorrectly; use frac.Parse`,
+			name,
+		)
+	}
+}
+
+/*
+Skips parentheses and conversions to integer types that aren't scaled, so that
+`Cents(int64(price * 100))` is treated like `Cents(price * 100)`.
+*/
+func unwrapIntConversions(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	for {
+		expr = ast.Unparen(expr)
+
+		call, ok := expr.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return expr
+		}
+		tv, ok := pass.TypesInfo.Types[call.Fun]
+		if !ok || !tv.IsType() || !isInteger(tv.Type) || scaledName(pass, tv.Type) != `` {
+			return expr
+		}
+		expr = call.Args[0]
+	}
+}
+
+func newFracCall(pass *analysis.Pass, call *ast.CallExpr) (fracCall, bool) {
+	fun := typeutil.StaticCallee(pass.TypesInfo, call)
+	if fun == nil || fun.Pkg() == nil || fun.Pkg().Path() != fracPath {
+		return fracCall{}, false
+	}
+
+	sig := fun.Type().(*types.Signature)
+	if sig.Recv() != nil {
+		return fracCall{}, false
+	}
+
+	out := fracCall{call: call, fun: fun}
+	name := fun.Name()
+	switch {
+	case strings.HasPrefix(name, `Append`), strings.HasPrefix(name, `Format`):
+		out.format = true
+	case strings.HasPrefix(name, `Parse`), strings.HasPrefix(name, `Unmarshal`):
+	default:
+		return fracCall{}, false
+	}
+
+	params := sig.Params()
+	for ind := 0; ind < params.Len() && ind < len(call.Args); ind++ {
+		if params.At(ind).Name() != `frac` {
+			continue
+		}
+		val := pass.TypesInfo.Types[call.Args[ind]].Value
+		if val != nil && val.Kind() == constant.Int {
+			out.frac, out.known = constant.Uint64Val(val)
+		}
+		break
+	}
+	return out, true
+}
+
+func recvTypeName(pass *analysis.Pass, decl *ast.FuncDecl) *types.TypeName {
+	fun, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return nil
+	}
+	recv := fun.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil
+	}
+
+	typ := recv.Type()
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil
+	}
+	return named.Obj()
+}
+
+// Returns the name of the scaled type, or an empty string.
+func scaledName(pass *analysis.Pass, typ types.Type) string {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return ``
+	}
+	if !pass.ImportObjectFact(named.Obj(), new(scaledType)) {
+		return ``
+	}
+	return types.TypeString(named, types.RelativeTo(pass.Pkg))
+}
+
+func isInteger(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsInteger != 0
+}
+
+func isFloat(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsFloat != 0
+}
@@ -0,0 +1,12 @@
+package fraccheck_test
+
+import (
+	"testing"
+
+	"github.com/mitranim/frac/fraccheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), fraccheck.Analyzer, `a`, `b`)
+}
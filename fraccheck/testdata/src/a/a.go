@@ -0,0 +1,58 @@
+package a
+
+import "github.com/mitranim/frac"
+
+type Cents int64 // want Cents:"scaled\\(frac 2\\)"
+
+func (self *Cents) UnmarshalText(src []byte) error {
+	num, err := frac.UnmarshalDec(src, 2)
+	*self = Cents(num)
+	return err
+}
+
+func (self Cents) MarshalText() ([]byte, error) {
+	return frac.AppendDec(nil, int64(self), 2)
+}
+
+func (self Cents) String() string {
+	out, _ := frac.FormatDec(int64(self), 3) // want `frac.FormatDec uses fraction 3, but frac.UnmarshalDec for Cents uses fraction 2`
+	return out
+}
+
+type Rate int64 // want Rate:"scaled\\(frac 4\\)"
+
+func (self *Rate) UnmarshalText(src []byte) error {
+	num, err := frac.Unmarshal(src, 4, 10)
+	*self = Rate(num)
+	return err
+}
+
+func (self Rate) MarshalText() ([]byte, error) {
+	return frac.Append(nil, int64(self), 2, 10) // want `frac.Append uses fraction 2, but frac.Unmarshal for Rate uses fraction 4`
+}
+
+// Not scaled: doesn't delegate to frac.
+type Count int64
+
+func (self Count) MarshalText() ([]byte, error) { return nil, nil }
+
+// Not scaled: not an integer.
+type Name string
+
+func (self Name) MarshalText() ([]byte, error) { return frac.AppendDec(nil, 0, 2) }
+
+func Convert(cents Cents, price float64, count Count, prec uint) {
+	_ = float64(cents) / 100      // want `conversion of scaled type Cents to float64 loses precision`
+	_ = float32(int64(cents))     // want `conversion of scaled type Cents to float32 loses precision`
+	_ = float64((cents))          // want `conversion of scaled type Cents to float64 loses precision`
+	_ = Cents(price * 100)        // want `conversion of float to scaled type Cents may round incorrectly`
+	_ = Cents(int64(price * 100)) // want `conversion of float to scaled type Cents may round incorrectly`
+	_ = Rate(uint32((price)))     // want `conversion of float to scaled type Rate may round incorrectly`
+	_ = int64(price * 100)
+	_ = float64(count)
+	_ = Count(price)
+	_ = Cents(100.0)
+	_ = Cents(count)
+	_ = int64(cents) * 2
+	_, _ = frac.FormatDec(int64(cents), prec)
+}
@@ -0,0 +1,8 @@
+package b
+
+import "a"
+
+func Total(val a.Cents, rate float64) {
+	_ = float64(val) * rate // want `conversion of scaled type a.Cents to float64 loses precision`
+	_ = a.Cents(rate)       // want `conversion of float to scaled type a.Cents may round incorrectly`
+}
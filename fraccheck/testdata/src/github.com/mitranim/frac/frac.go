@@ -0,0 +1,11 @@
+// Minimal stub of the library for analyzer tests.
+package frac
+
+func Parse(src string, frac uint, radix uint) (int64, error)              { return 0, nil }
+func ParseDec(src string, frac uint) (int64, error)                       { return 0, nil }
+func Unmarshal(src []byte, frac uint, radix uint) (int64, error)          { return 0, nil }
+func UnmarshalDec(src []byte, frac uint) (int64, error)                   { return 0, nil }
+func Format(num int64, frac uint, radix uint) (string, error)             { return ``, nil }
+func FormatDec(num int64, frac uint) (string, error)                      { return ``, nil }
+func Append(buf []byte, num int64, frac uint, radix uint) ([]byte, error) { return buf, nil }
+func AppendDec(buf []byte, num int64, frac uint) ([]byte, error)          { return buf, nil }
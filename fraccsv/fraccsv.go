@@ -0,0 +1,215 @@
+/*
+Transcodes numeric columns of CSV streams between fractional strings such as
+"12.50" and scaled integers such as "1250", optionally changing the fractional
+precision, via `github.com/mitranim/frac`. Useful for bulk migrations of
+stored amounts, such as moving a ledger from 2-digit to 4-digit storage.
+
+Invalid values are reported per row via a callback, without aborting the whole
+stream.
+*/
+package fraccsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mitranim/frac"
+)
+
+/*
+Describes how numbers are represented in a column. When `Scaled` is false,
+values are fractional strings such as "12.50", parsed via `frac.Parse` and
+formatted via `frac.Append` in the radix of the column. When `Scaled` is true,
+values are scaled integers such as "1250", always written in radix 10.
+`Frac` is the fractional precision in either case.
+*/
+type Repr struct {
+	Frac   uint
+	Scaled bool
+}
+
+/*
+Describes the conversion of one column. `Index` is the zero-based index of the
+column in each record. `Radix` applies to fractional strings on either side
+and to the precision itself; for example, at frac 2 in radix 10, the scaled
+integer 1250 represents "12.50". When `From.Frac` and `To.Frac` differ, values
+are rescaled via `frac.Rescale`, without rounding.
+*/
+type Column struct {
+	Index int
+	Radix uint
+	From  Repr
+	To    Repr
+}
+
+func (self Column) validate() error {
+	if self.Index < 0 {
+		return fmt.Errorf(`invalid column index %v`, self.Index)
+	}
+
+	// The radix applies to fractional strings, so it's validated together with
+	// the output precision when it's used for formatting.
+	opt := frac.FormatOpt{Radix: self.Radix}
+	if !self.To.Scaled {
+		opt.Frac = self.To.Frac
+	}
+	err := opt.Validate()
+	if err != nil {
+		return fmt.Errorf(`invalid column %v: %w`, self.Index, err)
+	}
+	return nil
+}
+
+/*
+Converts one value, appending the result to the buffer. Must be called after
+`Column.validate`.
+*/
+func (self Column) append(buf []byte, src string) ([]byte, error) {
+	var num int64
+	var err error
+
+	if self.From.Scaled {
+		num, err = strconv.ParseInt(src, 10, 64)
+	} else {
+		num, err = frac.Parse(src, self.From.Frac, self.Radix)
+	}
+	if err != nil {
+		return buf, err
+	}
+
+	num, err = frac.Rescale(num, self.From.Frac, self.To.Frac, self.Radix)
+	if err != nil {
+		return buf, err
+	}
+
+	if self.To.Scaled {
+		return strconv.AppendInt(buf, num, 10), nil
+	}
+	return frac.Append(buf, num, self.To.Frac, self.Radix)
+}
+
+/*
+Describes a value that couldn't be converted. `Row` is the 1-based number of
+the record in the input, including the header. `Index` is the zero-based index
+of the column, matching `Column.Index`. `Input` is the original value, which is
+empty when the record is missing the column. Supports `errors.Is` and
+`errors.As` via `Unwrap`, which allows detecting kinds such as `frac.ErrSyntax`.
+*/
+type RowError struct {
+	Row   int
+	Index int
+	Input string
+	Err   error
+}
+
+// Implement `error`.
+func (self RowError) Error() string {
+	return fmt.Sprintf(`row %v, column index %v: %v`, self.Row, self.Index, self.Err)
+}
+
+// Implement a hidden interface used by `errors.Is` and `errors.As`.
+func (self RowError) Unwrap() error { return self.Err }
+
+/*
+Transcodes CSV records. `Columns` lists the columns to convert; other columns
+are copied as-is.
+
+When `Header` is set, the first record is copied as-is.
+
+When `Keep` is set, records with invalid values are written unchanged, which
+preserves the row count. Otherwise they're omitted from the output.
+*/
+type Transcoder struct {
+	Columns []Column
+	Header  bool
+	Keep    bool
+}
+
+/*
+Reads all records from `src` and writes them to `dst`, converting the
+configured columns. Calls `report` for each record with invalid values, once
+per invalid value; `report` may be nil. When `report` returns an error,
+transcoding stops and returns that error.
+
+Returns the amount of records with invalid values. Reading and writing errors,
+including malformed CSV, abort transcoding. Flushes `dst` before returning.
+*/
+func (self Transcoder) Transcode(dst *csv.Writer, src *csv.Reader, report func(RowError) error) (invalid int, err error) {
+	for _, col := range self.Columns {
+		err = col.validate()
+		if err != nil {
+			return 0, fmt.Errorf(`unable to transcode CSV: %w`, err)
+		}
+	}
+
+	defer func() {
+		dst.Flush()
+		if err == nil {
+			err = dst.Error()
+		}
+	}()
+
+	var record, out []string
+	var buf []byte
+
+	for row := 1; ; row++ {
+		record, err = src.Read()
+		if errors.Is(err, io.EOF) {
+			return invalid, nil
+		}
+		if err != nil {
+			return invalid, err
+		}
+
+		if self.Header && row == 1 {
+			err = dst.Write(record)
+			if err != nil {
+				return invalid, err
+			}
+			continue
+		}
+
+		out = append(out[:0], record...)
+		ok := true
+
+		for _, col := range self.Columns {
+			var fail error
+			if col.Index >= len(record) {
+				fail = fmt.Errorf(`record has %v columns`, len(record))
+			} else {
+				buf, fail = col.append(buf[:0], record[col.Index])
+				if fail == nil {
+					out[col.Index] = string(buf)
+					continue
+				}
+			}
+
+			if ok {
+				invalid++
+				ok = false
+			}
+			if report != nil {
+				rowErr := RowError{Row: row, Index: col.Index, Err: fail}
+				if col.Index < len(record) {
+					rowErr.Input = record[col.Index]
+				}
+				err = report(rowErr)
+				if err != nil {
+					return invalid, err
+				}
+			}
+		}
+
+		if ok {
+			err = dst.Write(out)
+		} else if self.Keep {
+			err = dst.Write(record)
+		}
+		if err != nil {
+			return invalid, err
+		}
+	}
+}
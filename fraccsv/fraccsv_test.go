@@ -0,0 +1,146 @@
+package fraccsv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitranim/frac"
+)
+
+func TestTranscode(*testing.T) {
+	src := "id,amount,fee\n1,12.50,0.5\n2,-3,0\n3,1.255,1\n4,x,y\n5\n6,0.01,-0.01\n"
+	cols := []Column{
+		{Index: 1, Radix: 10, From: Repr{Frac: 2}, To: Repr{Frac: 4, Scaled: true}},
+		{Index: 2, Radix: 10, From: Repr{Frac: 2}, To: Repr{Frac: 2, Scaled: true}},
+	}
+
+	out, errs, invalid := testTranscode(Transcoder{Columns: cols, Header: true}, src)
+	testEqual(out, "id,amount,fee\n1,125000,50\n2,-30000,0\n6,100,-1\n")
+	testEqual(invalid, 3)
+	testEqual(errs, []string{
+		`row 4, column index 1: unable to parse "1.255" as number (radix 10, fraction 2): exponent exceeds allotted fractional precision`,
+		`row 5, column index 1: unable to parse "x" as number (radix 10, fraction 2): found non-digit character 'x'`,
+		`row 5, column index 2: unable to parse "y" as number (radix 10, fraction 2): found non-digit character 'y'`,
+		`row 6, column index 1: record has 1 columns`,
+		`row 6, column index 2: record has 1 columns`,
+	})
+
+	out, _, invalid = testTranscode(Transcoder{Columns: cols, Header: true, Keep: true}, src)
+	testEqual(out, "id,amount,fee\n1,125000,50\n2,-30000,0\n3,1.255,1\n4,x,y\n5\n6,100,-1\n")
+	testEqual(invalid, 3)
+}
+
+func TestTranscodeBack(*testing.T) {
+	cols := []Column{{Index: 0, Radix: 10, From: Repr{Frac: 4, Scaled: true}, To: Repr{Frac: 2}}}
+
+	out, errs, invalid := testTranscode(Transcoder{Columns: cols}, "125000\n-30000\n125050\n1.5\n99999999999999999999\n")
+	testEqual(out, "12.5\n-3\n")
+	testEqual(invalid, 3)
+	testEqual(errs, []string{
+		`row 3, column index 0: unable to rescale 125050 from fraction 4 to 2 (radix 10): non-zero digits exceed target precision`,
+		`row 4, column index 0: strconv.ParseInt: parsing "1.5": invalid syntax`,
+		`row 5, column index 0: strconv.ParseInt: parsing "99999999999999999999": value out of range`,
+	})
+
+	cols = []Column{{Index: 0, Radix: 16, From: Repr{Frac: 1}, To: Repr{Frac: 2}}}
+	out, _, invalid = testTranscode(Transcoder{Columns: cols}, "ff.8\n\"-1\"\n")
+	testEqual(out, "ff.8\n-1\n")
+	testEqual(invalid, 0)
+}
+
+func TestTranscodeKinds(*testing.T) {
+	cols := []Column{{Index: 0, Radix: 10, From: Repr{Frac: 2}, To: Repr{Frac: 18}}}
+	var kinds []error
+
+	_, err := Transcoder{Columns: cols}.Transcode(
+		csv.NewWriter(new(bytes.Buffer)),
+		csv.NewReader(strings.NewReader("1.x\n1.255\n10\n")),
+		func(err RowError) error {
+			for _, kind := range []error{frac.ErrSyntax, frac.ErrPrecision, frac.ErrRange} {
+				if errors.Is(err, kind) {
+					kinds = append(kinds, kind)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	testEqual(kinds, []error{frac.ErrSyntax, frac.ErrPrecision, frac.ErrRange})
+}
+
+func TestTranscodeAbort(*testing.T) {
+	cols := []Column{{Index: 0, Radix: 10, From: Repr{Frac: 2}, To: Repr{Frac: 2, Scaled: true}}}
+	var buf bytes.Buffer
+	stop := errors.New(`stop`)
+
+	invalid, err := Transcoder{Columns: cols}.Transcode(
+		csv.NewWriter(&buf),
+		csv.NewReader(strings.NewReader("1\nx\n2\n")),
+		func(RowError) error { return stop },
+	)
+	testEqual(err, stop)
+	testEqual(invalid, 1)
+	testEqual(buf.String(), "100\n")
+
+	_, err = Transcoder{Columns: cols}.Transcode(
+		csv.NewWriter(&buf),
+		csv.NewReader(strings.NewReader("\"1\n")),
+		nil,
+	)
+	if err == nil {
+		panic(fmt.Errorf(`expected malformed CSV to abort transcoding`))
+	}
+
+	for _, col := range []Column{
+		{Index: -1, Radix: 10},
+		{Index: 0, Radix: 37},
+		{Index: 0, Radix: 1, From: Repr{Scaled: true}, To: Repr{Scaled: true}},
+		{Index: 0, Radix: 10, To: Repr{Frac: 65}},
+	} {
+		_, err = Transcoder{Columns: []Column{col}}.Transcode(
+			csv.NewWriter(&buf),
+			csv.NewReader(strings.NewReader("1\n")),
+			nil,
+		)
+		if err == nil || !strings.HasPrefix(err.Error(), `unable to transcode CSV: `) {
+			panic(fmt.Errorf(`expected invalid column %+v to fail, got %v`, col, err))
+		}
+		if col.Radix != 10 && !errors.Is(err, frac.ErrRadix) {
+			panic(fmt.Errorf(`expected invalid radix %v to fail with ErrRadix, got %v`, col.Radix, err))
+		}
+	}
+}
+
+func testTranscode(trans Transcoder, src string) (string, []string, int) {
+	var buf bytes.Buffer
+	var errs []string
+
+	reader := csv.NewReader(strings.NewReader(src))
+	reader.FieldsPerRecord = -1
+
+	invalid, err := trans.Transcode(
+		csv.NewWriter(&buf),
+		reader,
+		func(err RowError) error {
+			errs = append(errs, err.Error())
+			return nil
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	return buf.String(), errs, invalid
+}
+
+func testEqual(act, exp interface{}) {
+	if !reflect.DeepEqual(act, exp) {
+		panic(fmt.Errorf("expected:\n%#v\ngot:\n%#v", exp, act))
+	}
+}
@@ -0,0 +1,70 @@
+//go:build go1.18
+// +build go1.18
+
+package frac
+
+import (
+	"math"
+	"testing"
+)
+
+/*
+Checks that `Parse` never panics, and that any accepted input formats back
+into text that parses into the same number. Precision and radix are reduced
+into the supported range so the fuzzer doesn't waste time on option errors.
+*/
+func FuzzParse(f *testing.F) {
+	f.Add(`0`, uint8(0), uint8(10))
+	f.Add(`-123.45`, uint8(2), uint8(10))
+	f.Add(`+0.001`, uint8(3), uint8(10))
+	f.Add(`ff.8`, uint8(1), uint8(16))
+	f.Add(`-1000000000000000000000000000000000000000000000000000000000000000`, uint8(0), uint8(2))
+	f.Add(`9223372036854775807`, uint8(0), uint8(10))
+	f.Add(`-9223372036854775808`, uint8(0), uint8(10))
+	f.Add(`1.2.3`, uint8(2), uint8(10))
+	f.Add(`z.z`, uint8(1), uint8(36))
+
+	f.Fuzz(func(_ *testing.T, src string, frac uint8, radix uint8) {
+		fracVal := uint(frac) % (fracMax + 1)
+		radixVal := radixMin + uint(radix)%(radixMax-radixMin+1)
+
+		num, err := Parse(src, fracVal, radixVal)
+		if err != nil {
+			return
+		}
+		testRoundTrip(num, fracVal, radixVal)
+		if radixVal == 10 {
+			testParseRat(src, fracVal)
+		}
+	})
+}
+
+// Checks that every number round-trips through `Format` and `Parse`.
+func FuzzAppend(f *testing.F) {
+	f.Add(int64(0), uint8(0), uint8(10))
+	f.Add(int64(-12345), uint8(2), uint8(10))
+	f.Add(int64(255), uint8(1), uint8(16))
+	f.Add(int64(math.MaxInt64), uint8(18), uint8(10))
+	f.Add(int64(math.MinInt64), uint8(64), uint8(2))
+	f.Add(int64(math.MinInt64), uint8(0), uint8(36))
+
+	f.Fuzz(func(_ *testing.T, num int64, frac uint8, radix uint8) {
+		testRoundTrip(
+			num,
+			uint(frac)%(fracMax+1),
+			radixMin+uint(radix)%(radixMax-radixMin+1),
+		)
+	})
+}
+
+// Differential fuzz target against `big.Rat`, see `TestParseRat`.
+func FuzzParseRat(f *testing.F) {
+	f.Add(`1.5`, uint8(1))
+	f.Add(`-0.001`, uint8(2))
+	f.Add(`92233720368547758.08`, uint8(2))
+	f.Add(`1e2`, uint8(0))
+
+	f.Fuzz(func(_ *testing.T, src string, frac uint8) {
+		testParseRat(src, uint(frac)%(fracMax+1))
+	})
+}
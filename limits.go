@@ -0,0 +1,80 @@
+package frac
+
+import "math"
+
+/*
+Returns the largest fractional precision at which the number 1 is still
+representable, for the given radix. For example, `MaxFrac(10)` is 18, because
+1 at frac 18 is 10^18, while 10^19 overflows `int64`.
+*/
+func MaxFrac(radix uint) (uint, error) {
+	if !validRadixAny(radix) {
+		return 0, errorf(ErrRadix, `unable to compute max fractional precision: unsupported radix %v`, radix)
+	}
+
+	table := pows[radix]
+	frac := uint(len(table) - 1)
+	for table[frac] > math.MaxInt64 {
+		frac--
+	}
+	return frac, nil
+}
+
+/*
+Returns the largest whole number representable at the given fractional
+precision and radix. For example, at frac 8 in radix 10, `int64` holds whole
+numbers up to 92_233_720_368. When the precision exceeds `MaxFrac`, the result
+is 0.
+*/
+func MaxWhole(frac uint, radix uint) (int64, error) {
+	if !validRadixAny(radix) {
+		return 0, errorf(ErrRadix, `unable to compute max whole number: unsupported radix %v`, radix)
+	}
+
+	pow, ok := powUint(radix, frac)
+	if !ok {
+		return 0, nil
+	}
+	return int64(math.MaxInt64 / pow), nil
+}
+
+/*
+Returns the smallest (negative) whole number representable at the given
+fractional precision and radix. For example, at frac 8 in radix 10, `int64`
+holds whole numbers down to -92_233_720_368. May differ from the negated
+`MaxWhole`: in radix 2 at frac 63, the smallest whole number is -1, while the
+largest is 0.
+*/
+func MinWhole(frac uint, radix uint) (int64, error) {
+	if !validRadixAny(radix) {
+		return 0, errorf(ErrRadix, `unable to compute min whole number: unsupported radix %v`, radix)
+	}
+
+	pow, ok := powUint(radix, frac)
+	if !ok {
+		return 0, nil
+	}
+
+	// The magnitude of `math.MinInt64` is 1<<63. When the power is 1, negating
+	// the quotient wraps around to `math.MinInt64`, which is correct.
+	return -int64((1 << 63) / pow), nil
+}
+
+/*
+Reports whether the input can be parsed at the given fractional precision and
+radix. Same as checking the error of `Parse`, but doesn't allocate, which makes
+it suitable for validating large amounts of data. Shortcut for
+`ParseOpt.Fits`.
+*/
+func Fits(src string, frac uint, radix uint) bool {
+	return ParseOpt{Frac: frac, Radix: radix}.Fits(src)
+}
+
+// Same as `Fits` but uses the options.
+func (self ParseOpt) Fits(src string) bool {
+	if !(self.validRadix() && self.validCase()) {
+		return false
+	}
+	_, fail := self.scan(self.trim(src))
+	return fail.code == failNone
+}
@@ -0,0 +1,142 @@
+package frac
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestMaxFrac(*testing.T) {
+	testMaxFrac(2, 62)
+	testMaxFrac(8, 20)
+	testMaxFrac(10, 18)
+	testMaxFrac(16, 15)
+	testMaxFrac(36, 12)
+	testMaxFrac(64, 10)
+
+	_, err := MaxFrac(65)
+	if !errors.Is(err, ErrRadix) {
+		panic(fmt.Errorf(`expected MaxFrac to reject radix 65 with ErrRadix, got %v`, err))
+	}
+
+	for radix := radixMin; radix <= radixMaxAlphabet; radix++ {
+		frac, err := MaxFrac(radix)
+		if err != nil {
+			panic(err)
+		}
+		if _, err := Pow(radix, frac); err != nil {
+			panic(fmt.Errorf(`expected %v^%v to fit: %v`, radix, frac, err))
+		}
+		if _, err := Pow(radix, frac+1); err == nil {
+			panic(fmt.Errorf(`expected %v^%v to overflow`, radix, frac+1))
+		}
+	}
+}
+
+func TestWhole(*testing.T) {
+	testWhole(0, 10, math.MinInt64, math.MaxInt64)
+	testWhole(2, 10, math.MinInt64/100, math.MaxInt64/100)
+	testWhole(8, 10, -92_233_720_368, 92_233_720_368)
+	testWhole(18, 10, -9, 9)
+	testWhole(19, 10, 0, 0)
+	testWhole(64, 10, 0, 0)
+	testWhole(62, 2, -2, 1)
+	testWhole(63, 2, -1, 0)
+	testWhole(64, 2, 0, 0)
+	testWhole(21, 8, -1, 0)
+	testWhole(15, 16, -8, 7)
+
+	for _, radix := range []uint{2, 10, 16, 36} {
+		for frac := uint(0); frac <= 20; frac++ {
+			max, err := MaxWhole(frac, radix)
+			if err != nil {
+				panic(err)
+			}
+			min, err := MinWhole(frac, radix)
+			if err != nil {
+				panic(err)
+			}
+			testFits(max, frac, radix, true)
+			testFits(min, frac, radix, true)
+			if frac > 0 {
+				testFits(max+1, frac, radix, false)
+				testFits(min-1, frac, radix, false)
+			}
+		}
+	}
+
+	if _, err := MaxWhole(2, 1); !errors.Is(err, ErrRadix) {
+		panic(fmt.Errorf(`expected MaxWhole to reject radix 1 with ErrRadix, got %v`, err))
+	}
+	if _, err := MinWhole(2, 1); !errors.Is(err, ErrRadix) {
+		panic(fmt.Errorf(`expected MinWhole to reject radix 1 with ErrRadix, got %v`, err))
+	}
+}
+
+func TestFits(*testing.T) {
+	testFitsSrc(`123.45`, 2, 10, true)
+	testFitsSrc(`123.456`, 2, 10, false)
+	testFitsSrc(maxInt64, 0, 10, true)
+	testFitsSrc(maxInt64+`0`, 0, 10, false)
+	testFitsSrc(`92233720368`, 8, 10, true)
+	testFitsSrc(`92233720369`, 8, 10, false)
+	testFitsSrc(``, 2, 10, false)
+	testFitsSrc(`12x`, 2, 10, false)
+	testFitsSrc(`1`, 2, 37, false)
+	testFitsSrc(`ff`, 2, 16, true)
+
+	if !(ParseOpt{Frac: 2, Radix: 10, Lenient: true}).Fits(` .5 `) {
+		panic(`expected lenient input to fit`)
+	}
+	if (ParseOpt{Frac: 2, Radix: 10, Case: CaseUpper + 1}).Fits(`1`) {
+		panic(`expected invalid case mode to be rejected`)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		Fits(`123.456`, 2, 10)
+		Fits(`12x`, 2, 10)
+		Fits(maxInt64+`0`, 0, 10)
+		Fits(`1`, 2, 37)
+	})
+	if allocs != 0 {
+		panic(fmt.Errorf(`expected Fits to not allocate, got %v allocations`, allocs))
+	}
+}
+
+func testMaxFrac(radix uint, exp uint) {
+	act, err := MaxFrac(radix)
+	if err != nil || act != exp {
+		panic(fmt.Errorf(`expected MaxFrac(%v) to be %v, got %v, %v`, radix, exp, act, err))
+	}
+}
+
+func testWhole(frac uint, radix uint, expMin int64, expMax int64) {
+	min, err := MinWhole(frac, radix)
+	if err != nil || min != expMin {
+		panic(fmt.Errorf(`expected MinWhole(%v, %v) to be %v, got %v, %v`, frac, radix, expMin, min, err))
+	}
+	max, err := MaxWhole(frac, radix)
+	if err != nil || max != expMax {
+		panic(fmt.Errorf(`expected MaxWhole(%v, %v) to be %v, got %v, %v`, frac, radix, expMax, max, err))
+	}
+}
+
+func testFits(whole int64, frac uint, radix uint, exp bool) {
+	src, err := Format(whole, 0, radix)
+	if err != nil {
+		panic(err)
+	}
+	testFitsSrc(src, frac, radix, exp)
+}
+
+func testFitsSrc(src string, frac uint, radix uint, exp bool) {
+	act := Fits(src, frac, radix)
+	if act != exp {
+		panic(fmt.Errorf(`expected Fits(%q, %v, %v) to be %v, got %v`, src, frac, radix, exp, act))
+	}
+	_, err := Parse(src, frac, radix)
+	if (err == nil) != exp {
+		panic(fmt.Errorf(`expected Fits(%q, %v, %v) to match Parse, got error %v`, src, frac, radix, err))
+	}
+}
@@ -0,0 +1,223 @@
+package frac
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*
+Mirrors the `google.type.Money` protobuf message, without depending on
+protobuf. `Units` is the whole amount, and `Nanos` is the fractional amount in
+units of 10^-9, in the range [-999_999_999, 999_999_999]. When both are
+non-zero, they must have the same sign. For example, -1.75 is represented as
+`Units: -1, Nanos: -750_000_000`.
+
+Convert to and from scaled integers via `ToMoney` and `FromMoney`. The radix is
+always 10.
+*/
+type Money struct {
+	CurrencyCode string
+	Units        int64
+	Nanos        int32
+}
+
+/*
+Mirrors the `google.type.Decimal` protobuf message, without depending on
+protobuf. `Value` is a decimal string such as "-12.50" or "1.25E+3".
+
+Convert to and from scaled integers via `ToDecimal` and `FromDecimal`. The
+radix is always 10.
+*/
+type Decimal struct {
+	Value string
+}
+
+const (
+	moneyNanosFrac = 9
+	moneyNanosMax  = 999_999_999
+)
+
+/*
+Converts a scaled integer with the given fractional precision (radix 10) into
+`Money` with the given currency code. Returns an error when the number has
+significant digits beyond 9 fractional digits, which `Money` can't represent.
+*/
+func ToMoney(num int64, frac uint, currency string) (Money, error) {
+	units, part, neg, err := Parts(num, frac, 10)
+	if err != nil {
+		return Money{}, err
+	}
+
+	var nanos uint64
+	if frac <= moneyNanosFrac {
+		pow, _ := powUint(10, moneyNanosFrac-frac)
+		nanos = part * pow
+	} else {
+		pow, ok := powUint(10, frac-moneyNanosFrac)
+		if (!ok && part != 0) || (ok && part%pow != 0) {
+			return Money{}, fmt.Errorf(
+				`unable to convert %v (fraction %v) to money: fractional part exceeds nanos precision`,
+				num, frac,
+			)
+		}
+		if ok {
+			nanos = part / pow
+		}
+	}
+
+	out := Money{CurrencyCode: currency, Units: units, Nanos: int32(nanos)}
+	if neg {
+		out.Nanos = -out.Nanos
+	}
+	return out, nil
+}
+
+/*
+Converts `Money` into a scaled integer with the given fractional precision
+(radix 10), ignoring the currency code. Returns an error when the nanos are out
+of range, when the signs of units and nanos differ, when the nanos have
+significant digits beyond the precision, or when the result overflows `int64`.
+*/
+func FromMoney(val Money, frac uint) (int64, error) {
+	if val.Nanos < -moneyNanosMax || val.Nanos > moneyNanosMax {
+		return 0, fmt.Errorf(`unable to convert money %+v: nanos out of range`, val)
+	}
+	if (val.Units > 0 && val.Nanos < 0) || (val.Units < 0 && val.Nanos > 0) {
+		return 0, fmt.Errorf(`unable to convert money %+v: signs of units and nanos differ`, val)
+	}
+
+	neg := val.Units < 0 || val.Nanos < 0
+	nanos := magnitude(int64(val.Nanos))
+
+	var part uint64
+	if frac >= moneyNanosFrac {
+		pow, ok := powUint(10, frac-moneyNanosFrac)
+		if nanos != 0 {
+			if !ok {
+				return 0, fmt.Errorf(`unable to convert money %+v (fraction %v): overflow of int64`, val, frac)
+			}
+			hi, lo := bits.Mul64(nanos, pow)
+			if hi != 0 {
+				return 0, fmt.Errorf(`unable to convert money %+v (fraction %v): overflow of int64`, val, frac)
+			}
+			part = lo
+		}
+	} else {
+		pow, _ := powUint(10, moneyNanosFrac-frac)
+		if nanos%pow != 0 {
+			return 0, fmt.Errorf(
+				`unable to convert money %+v (fraction %v): nanos exceed allotted fractional precision`,
+				val, frac,
+			)
+		}
+		part = nanos / pow
+	}
+
+	num, err := FromParts(val.Units, part, neg, frac, 10)
+	if err != nil {
+		return 0, fmt.Errorf(`unable to convert money %+v (fraction %v): %w`, val, frac, err)
+	}
+	return num, nil
+}
+
+/*
+Converts a scaled integer with the given fractional precision (radix 10) into
+`Decimal`, using `FormatDec`. The output is already in the normalized form
+recommended for `google.type.Decimal`.
+*/
+func ToDecimal(num int64, frac uint) (Decimal, error) {
+	str, err := FormatDec(num, frac)
+	return Decimal{str}, err
+}
+
+/*
+Parses `Decimal` into a scaled integer with the given fractional precision
+(radix 10). Supports the full syntax of `google.type.Decimal`: an optional
+sign, an optional integer part, an optional fractional part (at least one digit
+is required), and an optional exponent such as "E+3" or "e-2". Like `Parse`,
+rejects values that don't fit into the precision, without rounding.
+*/
+func FromDecimal(val Decimal, frac uint) (int64, error) {
+	src := val.Value
+	mant, exp := src, int64(0)
+
+	ind := strings.IndexAny(src, `eE`)
+	if ind >= 0 {
+		mant = src[:ind]
+		expSrc := src[ind+1:]
+		if !isDecimalExponent(expSrc) {
+			return 0, fmt.Errorf(`unable to parse decimal %q: invalid exponent %q`, src, expSrc)
+		}
+
+		var err error
+		exp, err = strconv.ParseInt(expSrc, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf(`unable to parse decimal %q: exponent out of range`, src)
+		}
+	}
+
+	if strings.IndexFunc(mant, unicode.IsSpace) >= 0 {
+		return 0, fmt.Errorf(`unable to parse decimal %q: unexpected whitespace`, src)
+	}
+
+	// A negative shift moves the point in the text rather than dividing the
+	// parsed mantissa, which might not fit into `int64` even when the result does.
+	shift := int64(frac) + exp
+	if shift < 0 {
+		mant = shiftPointLeft(mant, uint64(-shift))
+		shift = 0
+	}
+
+	num, err := ParseOpt{Frac: uint(shift), Radix: 10, Lenient: true}.Parse(mant)
+	if err != nil {
+		return 0, fmt.Errorf(`unable to parse decimal %q (fraction %v): %w`, src, frac, err)
+	}
+	return num, nil
+}
+
+/*
+Moves the decimal point in the mantissa to the left by the given amount of
+digits. When the point moves past all integer digits, the zeros between the
+point and the digits are omitted: at fractional precision 0, `Parse` accepts
+only zeros after the point, and the result is the same with or without them.
+Inputs without digits are returned as-is for `Parse` to reject.
+*/
+func shiftPointLeft(src string, count uint64) string {
+	var sign string
+	if len(src) > 0 && (src[0] == '+' || src[0] == '-') {
+		sign, src = src[:1], src[1:]
+	}
+
+	whole, part := src, ``
+	ind := strings.IndexByte(src, '.')
+	if ind >= 0 {
+		whole, part = src[:ind], src[ind+1:]
+	}
+	if whole == `` && part == `` {
+		return sign + src
+	}
+
+	if count >= uint64(len(whole)) {
+		return sign + `0.` + whole + part
+	}
+	ind = len(whole) - int(count)
+	return sign + whole[:ind] + `.` + whole[ind:] + part
+}
+
+func isDecimalExponent(src string) bool {
+	if len(src) > 0 && (src[0] == '+' || src[0] == '-') {
+		src = src[1:]
+	}
+	if len(src) == 0 {
+		return false
+	}
+	for _, char := range []byte(src) {
+		if !(char >= '0' && char <= '9') {
+			return false
+		}
+	}
+	return true
+}
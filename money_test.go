@@ -0,0 +1,166 @@
+package frac
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestToMoney(*testing.T) {
+	testToMoney(123_45, 2, Money{`USD`, 123, 450_000_000})
+	testToMoney(-123_45, 2, Money{`USD`, -123, -450_000_000})
+	testToMoney(-5, 2, Money{`USD`, 0, -50_000_000})
+	testToMoney(0, 2, Money{`USD`, 0, 0})
+	testToMoney(123, 0, Money{`USD`, 123, 0})
+	testToMoney(1_000000001, 9, Money{`USD`, 1, 1})
+	testToMoney(1_000000001_000, 12, Money{`USD`, 1, 1})
+	testToMoney(math.MaxInt64, 0, Money{`USD`, math.MaxInt64, 0})
+	testToMoney(math.MinInt64, 0, Money{`USD`, math.MinInt64, 0})
+	testToMoney(math.MinInt64, 9, Money{`USD`, -9_223_372_036, -854_775_808})
+	testToMoney(0, 100, Money{`USD`, 0, 0})
+
+	testToMoneyErr(1_000000001_1, 10, `exceeds nanos precision`)
+	testToMoneyErr(1, 100, `exceeds nanos precision`)
+}
+
+func TestFromMoney(*testing.T) {
+	testFromMoney(Money{`USD`, 123, 450_000_000}, 2, 123_45)
+	testFromMoney(Money{`USD`, -123, -450_000_000}, 2, -123_45)
+	testFromMoney(Money{`USD`, 0, -50_000_000}, 2, -5)
+	testFromMoney(Money{`USD`, -1, 0}, 2, -1_00)
+	testFromMoney(Money{`USD`, 1, 1}, 9, 1_000000001)
+	testFromMoney(Money{`USD`, 1, 1}, 12, 1_000000001_000)
+	testFromMoney(Money{`USD`, 0, 0}, 100, 0)
+	testFromMoney(Money{`USD`, math.MinInt64, 0}, 0, math.MinInt64)
+	testFromMoney(Money{`USD`, -9_223_372_036, -854_775_808}, 9, math.MinInt64)
+
+	testFromMoneyErr(Money{`USD`, 0, 1_000_000_000}, 2, `nanos out of range`)
+	testFromMoneyErr(Money{`USD`, 0, -1_000_000_000}, 2, `nanos out of range`)
+	testFromMoneyErr(Money{`USD`, 1, -1}, 9, `signs of units and nanos differ`)
+	testFromMoneyErr(Money{`USD`, -1, 1}, 9, `signs of units and nanos differ`)
+	testFromMoneyErr(Money{`USD`, 1, 1}, 8, `nanos exceed allotted fractional precision`)
+	testFromMoneyErr(Money{`USD`, 0, 1}, 100, `overflow`)
+	testFromMoneyErr(Money{`USD`, 0, 999_999_999}, 29, `overflow`)
+	testFromMoneyErr(Money{`USD`, math.MaxInt64, 0}, 2, `overflow`)
+	testFromMoneyErr(Money{`USD`, -9_223_372_036, -854_775_809}, 9, `overflow`)
+}
+
+func TestDecimal(*testing.T) {
+	testFromDecimal(`123.45`, 2, 123_45)
+	testFromDecimal(`-123.45`, 2, -123_45)
+	testFromDecimal(`+123.4`, 2, 123_40)
+	testFromDecimal(`.5`, 2, 50)
+	testFromDecimal(`-.5`, 2, -50)
+	testFromDecimal(`5.`, 2, 5_00)
+	testFromDecimal(`1.25E+3`, 2, 1250_00)
+	testFromDecimal(`1.25e3`, 0, 1250)
+	testFromDecimal(`2.5E-1`, 2, 25)
+	testFromDecimal(`2.5E0`, 1, 2_5)
+	testFromDecimal(`12500E-2`, 0, 125)
+	testFromDecimal(`-12500.000E-2`, 0, -125)
+	testFromDecimal(`0E-50`, 0, 0)
+	testFromDecimal(`0E+50`, 0, 0)
+	testFromDecimal(`-9223372036854775808E-18`, 18, math.MinInt64)
+	testFromDecimal(`100000000000000000000e-10`, 2, 10000000000_00)
+	testFromDecimal(`-100000000000000000000.000e-19`, 0, -10)
+	testFromDecimal(`1000.e-3`, 0, 1)
+	testFromDecimal(`.0e-5`, 0, 0)
+
+	testFromDecimalErr(``, 2, `empty input`)
+	testFromDecimalErr(`.`, 2, `unexpected end of input`)
+	testFromDecimalErr(`1.255`, 2, `exponent exceeds`)
+	testFromDecimalErr(`1.25E-1`, 2, `exponent exceeds`)
+	testFromDecimalErr(`12501E-2`, 0, `exponent exceeds`)
+	testFromDecimalErr(`1E-20`, 0, `exponent exceeds`)
+	testFromDecimalErr(`1E+19`, 0, `overflow`)
+	testFromDecimalErr(`100000000000000000000e-1`, 0, `overflow`)
+	testFromDecimalErr(`.E-1`, 0, `unexpected end of input`)
+	testFromDecimalErr(`-E-1`, 0, `unexpected end of input`)
+	testFromDecimalErr(`1E-2147483648`, 2, `exponent exceeds`)
+	testFromDecimalErr(`1E`, 2, `invalid exponent ""`)
+	testFromDecimalErr(`1E+`, 2, `invalid exponent "+"`)
+	testFromDecimalErr(`1E1.5`, 2, `invalid exponent "1.5"`)
+	testFromDecimalErr(`1E5E5`, 2, `invalid exponent "5E5"`)
+	testFromDecimalErr(`1E99999999999`, 2, `exponent out of range`)
+	testFromDecimalErr(` 1`, 2, `unexpected whitespace`)
+	testFromDecimalErr(`- 1`, 2, `unexpected whitespace`)
+	testFromDecimalErr(`NaN`, 2, `non-digit character 'N'`)
+	testFromDecimalErr(`0x10`, 2, `non-digit character 'x'`)
+
+	for _, num := range []int64{0, 1, -1, 123_45, -123_45, math.MaxInt64, math.MinInt64} {
+		for _, frac := range []uint{0, 2, 9, 18, 30} {
+			val, err := ToDecimal(num, frac)
+			if err != nil {
+				panic(err)
+			}
+			testFromDecimal(val.Value, frac, num)
+		}
+	}
+
+	val, err := ToDecimal(-123_40, 2)
+	if err != nil || val.Value != `-123.4` {
+		panic(fmt.Errorf(`unexpected ToDecimal result: %v, %v`, val, err))
+	}
+}
+
+func testToMoney(num int64, frac uint, exp Money) {
+	act, err := ToMoney(num, frac, exp.CurrencyCode)
+	if err != nil {
+		panic(fmt.Errorf(`failed to convert %v (frac %v) to money: %+v`, num, frac, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected to convert %v (frac %v) to %+v, got %+v`, num, frac, exp, act))
+	}
+	testFromMoney(act, frac, num)
+}
+
+func testToMoneyErr(num int64, frac uint, msg string) {
+	res, err := ToMoney(num, frac, `USD`)
+	if err == nil {
+		panic(fmt.Errorf(`expected converting %v (frac %v) to money to fail; instead got %+v`, num, frac, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from converting %v (frac %v) to money to contain %q, got %q`, num, frac, msg, err))
+	}
+}
+
+func testFromMoney(val Money, frac uint, exp int64) {
+	act, err := FromMoney(val, frac)
+	if err != nil {
+		panic(fmt.Errorf(`failed to convert %+v (frac %v): %+v`, val, frac, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected to convert %+v (frac %v) to %v, got %v`, val, frac, exp, act))
+	}
+}
+
+func testFromMoneyErr(val Money, frac uint, msg string) {
+	res, err := FromMoney(val, frac)
+	if err == nil {
+		panic(fmt.Errorf(`expected converting %+v (frac %v) to fail; instead got %v`, val, frac, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from converting %+v (frac %v) to contain %q, got %q`, val, frac, msg, err))
+	}
+}
+
+func testFromDecimal(src string, frac uint, exp int64) {
+	act, err := FromDecimal(Decimal{src}, frac)
+	if err != nil {
+		panic(fmt.Errorf(`failed to parse decimal %q (frac %v): %+v`, src, frac, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected to parse decimal %q (frac %v) into %v, got %v`, src, frac, exp, act))
+	}
+}
+
+func testFromDecimalErr(src string, frac uint, msg string) {
+	res, err := FromDecimal(Decimal{src}, frac)
+	if err == nil {
+		panic(fmt.Errorf(`expected parsing decimal %q (frac %v) to fail; instead got %v`, src, frac, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from parsing decimal %q (frac %v) to contain %q, got %q`, src, frac, msg, err))
+	}
+}
@@ -0,0 +1,121 @@
+package frac
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+/*
+Splits a scaled integer into its whole and fractional parts. For example, at
+frac 2 in radix 10, the number 12345 ("123.45") is split into 123 and 45, and
+the number -12345 ("-123.45") is split into -123 and 45.
+
+The whole part carries the sign. The fractional part is always non-negative;
+its value is virtually "divided" by `radix^frac`, and its leading zeros are
+significant: at frac 2, the number 5 ("0.05") has the fractional part 5. Because
+the whole part may be 0, the sign of the number is returned separately as
+`neg`. `math.MinInt64` is supported at any precision.
+
+Uses the same splitting as `Append`. The inverse of `FromParts`.
+*/
+func Parts(num int64, frac uint, radix uint) (whole int64, part uint64, neg bool, err error) {
+	if !validRadixAny(radix) {
+		return 0, 0, false, errorf(ErrRadix, `unable to split %v into parts: unsupported radix %v`, num, radix)
+	}
+
+	neg = num < 0
+	uwhole, part := split(magnitude(num), frac, radix)
+	whole = int64(uwhole)
+	if neg {
+		// When `num` is `math.MinInt64` at frac 0, this wraps around to itself.
+		whole = -whole
+	}
+	return whole, part, neg, nil
+}
+
+/*
+Combines whole and fractional parts into a scaled integer. The inverse of
+`Parts`; see it for the meaning of the arguments. Returns an error when the
+sign of the whole part contradicts `neg`, when the fractional part doesn't fit
+into the fractional precision, or when the result overflows `int64`.
+*/
+func FromParts(whole int64, part uint64, neg bool, frac uint, radix uint) (int64, error) {
+	if !validRadixAny(radix) {
+		return 0, errorf(ErrRadix, `unable to combine parts %v and %v: unsupported radix %v`, whole, part, radix)
+	}
+
+	if (whole < 0 && !neg) || (whole > 0 && neg) {
+		return 0, fmt.Errorf(`unable to combine parts %v and %v: sign of whole part contradicts negative flag %v`, whole, part, neg)
+	}
+
+	uwhole := magnitude(whole)
+
+	var limit uint64 = math.MaxInt64
+	if neg {
+		limit = -math.MinInt64
+	}
+
+	pow, ok := powUint(radix, frac)
+	if ok && part >= pow {
+		return 0, fmt.Errorf(
+			`unable to combine parts %v and %v: fractional part exceeds precision (radix %v, fraction %v)`,
+			whole, part, radix, frac,
+		)
+	}
+
+	var unum uint64
+	if uwhole == 0 {
+		unum = part
+	} else {
+		if !ok {
+			return 0, errPartsOverflow(whole, part)
+		}
+		hi, lo := bits.Mul64(uwhole, pow)
+		if hi != 0 {
+			return 0, errPartsOverflow(whole, part)
+		}
+		var carry uint64
+		unum, carry = bits.Add64(lo, part, 0)
+		if carry != 0 {
+			return 0, errPartsOverflow(whole, part)
+		}
+	}
+
+	if unum > limit {
+		return 0, errPartsOverflow(whole, part)
+	}
+	if neg {
+		return -int64(unum), nil
+	}
+	return int64(unum), nil
+}
+
+func errPartsOverflow(whole int64, part uint64) error {
+	return fmt.Errorf(`unable to combine parts %v and %v: overflow of int64`, whole, part)
+}
+
+/*
+Splits the magnitude of a scaled integer into whole and fractional parts. When
+`radix^frac` exceeds `uint64`, the whole part is 0. Must be called with a valid
+radix.
+*/
+func split(unum uint64, frac uint, radix uint) (uint64, uint64) {
+	pow, ok := powUint(radix, frac)
+	if !ok {
+		return 0, unum
+	}
+	whole := unum / pow
+	return whole, unum - whole*pow
+}
+
+/*
+Returns the magnitude of the number. Also correct for `math.MinInt64`, whose
+negation wraps around to itself, but converts to 1<<63.
+*/
+func magnitude(num int64) uint64 {
+	if num < 0 {
+		return uint64(-num)
+	}
+	return uint64(num)
+}
@@ -0,0 +1,98 @@
+package frac
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParts(*testing.T) {
+	testParts(123_45, 2, 10, 123, 45, false)
+	testParts(-123_45, 2, 10, -123, 45, true)
+	testParts(5, 2, 10, 0, 5, false)
+	testParts(-5, 2, 10, 0, 5, true)
+	testParts(0, 2, 10, 0, 0, false)
+	testParts(123, 0, 10, 123, 0, false)
+	testParts(math.MaxInt64, 0, 10, math.MaxInt64, 0, false)
+	testParts(math.MinInt64, 0, 10, math.MinInt64, 0, true)
+	testParts(math.MinInt64, 2, 10, -92233720368547758, 8, true)
+	testParts(math.MinInt64, 63, 2, -1, 0, true)
+	testParts(math.MinInt64, 64, 2, 0, 1<<63, true)
+	testParts(math.MaxInt64, 19, 10, 0, math.MaxInt64, false)
+	testParts(math.MaxInt64, 100, 10, 0, math.MaxInt64, false)
+	testParts(0xff_8, 1, 16, 0xff, 8, false)
+
+	_, _, _, err := Parts(1, 2, 65)
+	if !errors.Is(err, ErrRadix) || !strings.Contains(err.Error(), `unsupported radix 65`) {
+		panic(fmt.Errorf(`expected Parts to reject radix 65 with ErrRadix, got %v`, err))
+	}
+}
+
+func TestFromParts(*testing.T) {
+	testFromPartsErr(1, 0, true, 2, 10, `contradicts negative flag`)
+	testFromPartsErr(-1, 0, false, 2, 10, `contradicts negative flag`)
+	testFromPartsErr(1, 100, false, 2, 10, `fractional part exceeds precision`)
+	testFromPartsErr(math.MaxInt64, 0, false, 1, 10, `overflow`)
+	testFromPartsErr(92233720368547758, 8, false, 2, 10, `overflow`)
+	testFromPartsErr(-92233720368547758, 9, true, 2, 10, `overflow`)
+	testFromPartsErr(1, 0, false, 64, 2, `overflow`)
+	testFromPartsErr(0, 1<<63, false, 64, 2, `overflow`)
+	testFromPartsErr(1, 1, false, 1000, 10, `overflow`)
+	testFromPartsErr(0, 0, false, 2, 1, `unsupported radix 1`)
+	if _, err := FromParts(0, 0, false, 2, 1); !errors.Is(err, ErrRadix) {
+		panic(fmt.Errorf(`expected FromParts to reject radix 1 with ErrRadix, got %v`, err))
+	}
+
+	testFromParts(92233720368547758, 7, false, 2, 10, math.MaxInt64)
+	testFromParts(0, 0, true, 2, 10, 0)
+	testFromParts(0, math.MaxInt64, false, 1000, 10, math.MaxInt64)
+	testFromParts(0, 1<<63, true, 1000, 10, math.MinInt64)
+
+	for _, radix := range []uint{2, 10, 16, 36, 64} {
+		for _, frac := range []uint{0, 1, 2, 10, 30, 63, 64, 65} {
+			for _, num := range []int64{0, 1, -1, 123_45, -123_45, math.MaxInt64, math.MinInt64, math.MinInt64 + 1} {
+				whole, part, neg, err := Parts(num, frac, radix)
+				if err != nil {
+					panic(err)
+				}
+				testFromParts(whole, part, neg, frac, radix, num)
+			}
+		}
+	}
+}
+
+func testParts(num int64, frac uint, radix uint, expWhole int64, expPart uint64, expNeg bool) {
+	whole, part, neg, err := Parts(num, frac, radix)
+	if err != nil {
+		panic(fmt.Errorf(`failed to split %v (frac %v, radix %v): %+v`, num, frac, radix, err))
+	}
+	if whole != expWhole || part != expPart || neg != expNeg {
+		panic(fmt.Errorf(
+			`expected to split %v (frac %v, radix %v) into %v, %v, %v; got %v, %v, %v`,
+			num, frac, radix, expWhole, expPart, expNeg, whole, part, neg,
+		))
+	}
+	testFromParts(whole, part, neg, frac, radix, num)
+}
+
+func testFromParts(whole int64, part uint64, neg bool, frac uint, radix uint, exp int64) {
+	act, err := FromParts(whole, part, neg, frac, radix)
+	if err != nil {
+		panic(fmt.Errorf(`failed to combine %v, %v, %v (frac %v, radix %v): %+v`, whole, part, neg, frac, radix, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected to combine %v, %v, %v (frac %v, radix %v) into %v, got %v`, whole, part, neg, frac, radix, exp, act))
+	}
+}
+
+func testFromPartsErr(whole int64, part uint64, neg bool, frac uint, radix uint, msg string) {
+	res, err := FromParts(whole, part, neg, frac, radix)
+	if err == nil {
+		panic(fmt.Errorf(`expected combining %v, %v, %v (frac %v, radix %v) to fail; instead got %v`, whole, part, neg, frac, radix, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from combining %v, %v, %v to contain %q, got %q`, whole, part, neg, msg, err))
+	}
+}
@@ -0,0 +1,97 @@
+package frac
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+/*
+Returns `radix` raised to the power of `frac`: the scaled representation of the
+number 1 at the given fractional precision. For example, `Pow(10, 2)` is 100,
+which converts between whole units and cents. Returns an error when the radix
+is unsupported or when the result overflows `int64`.
+
+Uses precomputed tables.
+*/
+func Pow(radix uint, frac uint) (int64, error) {
+	if !validRadixAny(radix) {
+		return 0, errorf(ErrRadix, `unable to compute %v^%v: unsupported radix %v`, radix, frac, radix)
+	}
+
+	pow, ok := powUint(radix, frac)
+	if !ok || pow > math.MaxInt64 {
+		return 0, fmt.Errorf(`unable to compute %v^%v: overflow of int64`, radix, frac)
+	}
+	return int64(pow), nil
+}
+
+/*
+For each radix, holds its powers that fit into `uint64`, starting with the
+power 0. The length minus one is the largest safe exponent for that radix.
+*/
+var pows = func() (out [radixMaxAlphabet + 1][]uint64) {
+	for radix := radixMin; radix <= radixMaxAlphabet; radix++ {
+		pow := uint64(1)
+		for {
+			out[radix] = append(out[radix], pow)
+			hi, lo := bits.Mul64(pow, uint64(radix))
+			if hi != 0 {
+				break
+			}
+			pow = lo
+		}
+	}
+	return
+}()
+
+/*
+For each radix, holds the range of numbers that can be multiplied by the radix
+without overflowing `int64`, avoiding a division per parsed digit.
+*/
+var mulLimits = func() (out [radixMaxAlphabet + 1]struct{ min, max int64 }) {
+	for radix := radixMin; radix <= radixMaxAlphabet; radix++ {
+		out[radix].min = math.MinInt64 / int64(radix)
+		out[radix].max = math.MaxInt64 / int64(radix)
+	}
+	return
+}()
+
+// Must be called with a valid radix.
+func powUint(radix uint, exp uint) (uint64, bool) {
+	table := pows[radix]
+	if exp < uint(len(table)) {
+		return table[exp], true
+	}
+	return 0, false
+}
+
+/*
+Multiplies the number by `radix^exp`, checking for overflow. Used for padding
+parsed numbers with trailing zeros. Must be called with a valid radix.
+*/
+func scale(num int64, radix uint, exp uint) (int64, parseCode) {
+	if num == 0 || exp == 0 {
+		return num, failNone
+	}
+
+	pow, ok := powUint(radix, exp)
+	if ok {
+		if num > 0 {
+			hi, lo := bits.Mul64(uint64(num), pow)
+			if hi == 0 && lo <= math.MaxInt64 {
+				return int64(lo), failNone
+			}
+		} else {
+			hi, lo := bits.Mul64(uint64(-num), pow)
+			if hi == 0 && lo <= -math.MinInt64 {
+				return -int64(lo), failNone
+			}
+		}
+	}
+
+	if num > 0 {
+		return 0, failOverflow
+	}
+	return 0, failUnderflow
+}
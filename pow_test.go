@@ -0,0 +1,109 @@
+package frac
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestPow(*testing.T) {
+	testPow(10, 0, 1)
+	testPow(10, 2, 100)
+	testPow(10, 18, 1_000_000_000_000_000_000)
+	testPow(2, 62, 1<<62)
+	testPow(16, 15, 1<<60)
+	testPow(64, 10, 1<<60)
+	testPowErr(10, 19, `overflow of int64`)
+	testPowErr(2, 63, `overflow of int64`)
+	testPowErr(2, 1000, `overflow of int64`)
+	testPowErr(1, 2, `unsupported radix 1`)
+	testPowErr(65, 2, `unsupported radix 65`)
+	if _, err := Pow(65, 2); !errors.Is(err, ErrRadix) {
+		panic(fmt.Errorf(`expected Pow to reject radix 65 with ErrRadix, got %v`, err))
+	}
+
+	for radix := radixMin; radix <= radixMaxAlphabet; radix++ {
+		exp := big.NewInt(1)
+		for frac := uint(0); frac < 70; frac++ {
+			act, err := Pow(radix, frac)
+			if exp.IsInt64() {
+				if err != nil || act != exp.Int64() {
+					panic(fmt.Errorf(`expected %v^%v to be %v, got %v, %v`, radix, frac, exp, act, err))
+				}
+			} else if err == nil {
+				panic(fmt.Errorf(`expected %v^%v to overflow, got %v`, radix, frac, act))
+			}
+			exp.Mul(exp, big.NewInt(int64(radix)))
+		}
+	}
+}
+
+func TestParsePadding(*testing.T) {
+	testParse(`-1`, 63, 2, math.MinInt64)
+	testParseErr(`1`, `overflow`, 2, 63, 64, 1000)
+	testParseErr(`-1`, `underflow`, 2, 64, 1000)
+	testParse(`0`, 1000, 10, 0)
+	testParse(`-0.000`, 1000, 10, 0)
+	testParse(`9.223372036854775807`, 18, 10, math.MaxInt64)
+	testParse(`-9.223372036854775808`, 18, 10, math.MinInt64)
+	testParse(`9.22`, 18, 10, 9_220000000000000000)
+	testParseErr(`9.23`, `overflow`, 10, 18)
+	testParseErr(`-9.23`, `underflow`, 10, 18)
+	testParse(`-8`, 15, 16, math.MinInt64)
+	testParseErr(`8`, `overflow`, 16, 15)
+}
+
+func TestParseOverflow(*testing.T) {
+	testParseErr(`21000000000000000000`, `overflow`, 10, 0)
+	testParseErr(`-21000000000000000000`, `underflow`, 10, 0)
+	testParseErr(`2100000000000000000.0`, `overflow`, 10, 1)
+	testParseErr(`1000000000000000000000000000000`, `overflow`, 10, 0)
+	testParseErr(strings.Repeat(`z`, 13), `overflow`, 36, 0)
+	testParseErr(`18446744073709551616`, `overflow`, 10, 0)
+
+	for radix := radixMin; radix <= radixMax; radix++ {
+		for _, val := range []*big.Int{
+			big.NewInt(math.MaxInt64),
+			big.NewInt(math.MinInt64),
+			new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1)),
+			new(big.Int).Sub(big.NewInt(math.MinInt64), big.NewInt(1)),
+			new(big.Int).Mul(big.NewInt(math.MaxInt64), big.NewInt(int64(radix)-1)),
+			new(big.Int).Mul(big.NewInt(math.MinInt64), big.NewInt(int64(radix)-1)),
+			new(big.Int).Lsh(big.NewInt(1), 64),
+			new(big.Int).Div(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(int64(radix))),
+		} {
+			src := val.Text(int(radix))
+			act, err := Parse(src, 0, radix)
+			if val.IsInt64() {
+				if err != nil || act != val.Int64() {
+					panic(fmt.Errorf(`expected %q (radix %v) to parse into %v, got %v, %v`, src, radix, val, act, err))
+				}
+			} else if err == nil {
+				panic(fmt.Errorf(`expected %q (radix %v) to overflow, got %v`, src, radix, act))
+			}
+		}
+	}
+}
+
+func testPow(radix uint, frac uint, exp int64) {
+	act, err := Pow(radix, frac)
+	if err != nil {
+		panic(fmt.Errorf(`failed to compute %v^%v: %+v`, radix, frac, err))
+	}
+	if exp != act {
+		panic(fmt.Errorf(`expected %v^%v to be %v, got %v`, radix, frac, exp, act))
+	}
+}
+
+func testPowErr(radix uint, frac uint, msg string) {
+	res, err := Pow(radix, frac)
+	if err == nil {
+		panic(fmt.Errorf(`expected computing %v^%v to fail; instead got %v`, radix, frac, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from computing %v^%v to contain %q, got %q`, radix, frac, msg, err))
+	}
+}
@@ -0,0 +1,166 @@
+package frac
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+/*
+Checks `Parse(Format(num, frac, radix), frac, radix) == num` for every supported
+radix and precision, over boundary values and a fixed pseudo-random sample.
+*/
+func TestRoundTripExhaustive(*testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for radix := uint(radixMin); radix <= radixMax; radix++ {
+		for frac := uint(0); frac <= fracMax; frac++ {
+			for _, num := range roundTripNums(frac, radix) {
+				testRoundTrip(num, frac, radix)
+			}
+			for range counter(16) {
+				testRoundTrip(int64(rnd.Uint64()), frac, radix)
+			}
+		}
+	}
+}
+
+func TestRoundTripAlphabet(*testing.T) {
+	for _, alpha := range []*Alphabet{AlphabetBase62, AlphabetCrockford} {
+		radix := uint(alpha.Len())
+		for frac := uint(0); frac <= fracMax; frac++ {
+			for _, num := range roundTripNums(frac, radix) {
+				src, err := FormatOpt{Frac: frac, Radix: radix, Alphabet: alpha}.Format(num)
+				if err != nil {
+					panic(err)
+				}
+				act, err := ParseOpt{Frac: frac, Radix: radix, Alphabet: alpha}.Parse(src)
+				if err != nil {
+					panic(fmt.Errorf(`failed to round-trip %v (frac %v, alphabet %q) via %q: %+v`, num, frac, alpha, src, err))
+				}
+				if act != num {
+					panic(fmt.Errorf(`expected %v (frac %v, alphabet %q) to round-trip via %q, got %v`, num, frac, alpha, src, act))
+				}
+			}
+		}
+	}
+}
+
+/*
+Differential test: for radix 10, any input accepted by `Parse` must be accepted
+by `big.Rat` with the same value, and any input in the shared syntax whose
+exact value fits the precision must be accepted by `Parse`.
+*/
+func TestParseRat(*testing.T) {
+	srcs := []string{
+		`0`, `-0`, `+0`, `1`, `-1`, `+1`, `00`, `007`, `0.0`, `1.5`, `-1.5`,
+		`1.50`, `1.05`, `-0.001`, `123.456`, `1.`, `.1`, `-.1`, `1..2`, `--1`,
+		`+-1`, `1e2`, `1/2`, `0x10`, ` 1`, `1 `, ``, `-`, `.`,
+		`9223372036854775807`, `9223372036854775808`,
+		`-9223372036854775808`, `-9223372036854775809`,
+		`92233720368547758.07`, `92233720368547758.08`,
+		`-92233720368547758.08`, `-92233720368547758.09`,
+		`0.000000000000000000000000000000001`,
+		`0.000000000000000000000000000000000`,
+		`100000000000000000000000000000000000000`,
+	}
+
+	for _, src := range srcs {
+		for frac := uint(0); frac <= fracMax; frac++ {
+			testParseRat(src, frac)
+		}
+	}
+}
+
+func roundTripNums(frac uint, radix uint) []int64 {
+	out := []int64{
+		0, 1, -1, 2, -2,
+		int64(radix), -int64(radix), int64(radix) - 1, -int64(radix) + 1,
+		math.MaxInt64, math.MinInt64, math.MaxInt64 - 1, math.MinInt64 + 1,
+	}
+
+	pow, err := Pow(radix, frac)
+	if err == nil {
+		out = append(out, pow, -pow, pow-1, -pow+1, pow+1, -pow-1)
+	}
+	return out
+}
+
+func testRoundTrip(num int64, frac uint, radix uint) {
+	src, err := Format(num, frac, radix)
+	if frac > fracMax {
+		if err == nil {
+			panic(fmt.Errorf(`expected formatting %v (frac %v, radix %v) to fail; instead got %q`, num, frac, radix, src))
+		}
+		return
+	}
+	if err != nil {
+		panic(fmt.Errorf(`failed to format %v (frac %v, radix %v): %+v`, num, frac, radix, err))
+	}
+
+	act, err := Parse(src, frac, radix)
+	if err != nil {
+		panic(fmt.Errorf(`failed to round-trip %v (frac %v, radix %v) via %q: %+v`, num, frac, radix, src, err))
+	}
+	if act != num {
+		panic(fmt.Errorf(`expected %v (frac %v, radix %v) to round-trip via %q, got %v`, num, frac, radix, src, act))
+	}
+}
+
+func testParseRat(src string, frac uint) {
+	act, err := Parse(src, frac, 10)
+
+	rat, ok := new(big.Rat).SetString(src)
+	if err == nil && !ok {
+		panic(fmt.Errorf(`parsed %q (frac %v) into %v, but big.Rat rejects it`, src, frac, act))
+	}
+	if !ok || !isSimpleDec(src) {
+		return
+	}
+
+	rat.Mul(rat, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(frac)), nil)))
+	fits := rat.IsInt() && rat.Num().IsInt64()
+
+	if err != nil {
+		if fits {
+			panic(fmt.Errorf(`failed to parse %q (frac %v), but big.Rat produces %v: %+v`, src, frac, rat.Num(), err))
+		}
+		return
+	}
+	if !fits || rat.Num().Int64() != act {
+		panic(fmt.Errorf(`parsed %q (frac %v) into %v, but big.Rat produces %v`, src, frac, act, rat.RatString()))
+	}
+}
+
+/*
+Reports whether the input uses the syntax shared by `Parse` and `big.Rat` for
+radix 10: an optional sign, digits, and optionally a point followed by digits.
+*/
+func isSimpleDec(src string) bool {
+	if len(src) > 0 && (src[0] == '+' || src[0] == '-') {
+		src = src[1:]
+	}
+
+	whole := digitsLen(src)
+	if whole == 0 {
+		return false
+	}
+	src = src[whole:]
+	if src == `` {
+		return true
+	}
+	if src[0] != '.' {
+		return false
+	}
+	src = src[1:]
+	return src != `` && digitsLen(src) == len(src)
+}
+
+func digitsLen(src string) (out int) {
+	for out < len(src) && src[out] >= '0' && src[out] <= '9' {
+		out++
+	}
+	return
+}
@@ -0,0 +1,178 @@
+package frac
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Unit of a rate string, determined by its suffix. A rate is stored as a scaled
+decimal ratio: at frac 4, "2.9%", "29‰", "290bps" and "0.029" are all parsed
+into 290, which represents 0.029.
+*/
+type RateUnit byte
+
+const (
+	// Plain ratio without a suffix, such as "0.029".
+	RateRatio RateUnit = iota
+
+	// Percent, such as "2.9%": hundredths.
+	RatePercent
+
+	// Per mille, such as "29‰": thousandths.
+	RatePermille
+
+	// Basis points, such as "290bps": ten-thousandths.
+	RateBps
+)
+
+// Returns the suffix of the unit in rate strings.
+func (self RateUnit) Suffix() string {
+	switch self {
+	case RatePercent:
+		return `%`
+	case RatePermille:
+		return `‰`
+	case RateBps:
+		return `bps`
+	default:
+		return ``
+	}
+}
+
+// Decimal exponent of the unit: 2 for percent, 4 for basis points.
+func (self RateUnit) exp() uint {
+	switch self {
+	case RatePercent:
+		return 2
+	case RatePermille:
+		return 3
+	case RateBps:
+		return 4
+	default:
+		return 0
+	}
+}
+
+/*
+Parses a rate string such as "2.9%", "1.5‰", "25bps" or "0.029" into a scaled
+decimal ratio with the given fractional precision. The unit is detected from
+the suffix; see `RateUnit`. Spaces between the number and the suffix are
+allowed, as in "25 bps". Like `Parse`, rejects values that don't fit into the
+precision, without rounding: "2.95%" requires at least frac 4.
+*/
+func ParseRate(src string, frac uint) (int64, error) {
+	unit, body := rateUnit(src)
+	exp := unit.exp()
+
+	if frac >= exp {
+		num, err := Parse(body, frac-exp, 10)
+		if err != nil {
+			return 0, fmt.Errorf(`unable to parse rate %q: %w`, src, err)
+		}
+		return num, nil
+	}
+
+	num, err := Parse(body, frac, 10)
+	if err == nil {
+		num, err = Rescale(num, exp, 0, 10)
+	}
+	if err != nil {
+		return 0, fmt.Errorf(`unable to parse rate %q (fraction %v): %w`, src, frac, err)
+	}
+	return num, nil
+}
+
+func rateUnit(src string) (RateUnit, string) {
+	for _, unit := range [...]RateUnit{RatePercent, RatePermille, RateBps} {
+		suffix := unit.Suffix()
+		if len(src) > len(suffix) && strings.HasSuffix(src, suffix) {
+			return unit, strings.TrimRight(src[:len(src)-len(suffix)], ` `)
+		}
+	}
+	return RateRatio, src
+}
+
+// Same as `AppendRate` but returns a string.
+func FormatRate(rate int64, frac uint, unit RateUnit) (string, error) {
+	buf, err := AppendRate(nil, rate, frac, unit)
+	return bytesToMutableString(buf), err
+}
+
+/*
+Formats a scaled decimal ratio as a rate string in the given unit, appending
+the result to the buffer. For example, 290 at frac 4 is formatted as "2.9%" for
+`RatePercent` and as "290bps" for `RateBps`. The number is formatted via
+`Append`, without rounding. The inverse of `ParseRate`.
+*/
+func AppendRate(buf []byte, rate int64, frac uint, unit RateUnit) ([]byte, error) {
+	if !(unit <= RateBps) {
+		return buf, fmt.Errorf(`unable to format rate %v: unsupported rate unit %v`, rate, unit)
+	}
+
+	exp := unit.exp()
+	var err error
+
+	if frac >= exp {
+		buf, err = Append(buf, rate, frac-exp, 10)
+	} else {
+		num, code := scale(rate, 10, exp-frac)
+		if code != failNone {
+			return buf, errorf(
+				ErrRange, `unable to format rate %v (fraction %v) in %v: %v of int64`,
+				rate, frac, unit.Suffix(), rangeName(code),
+			)
+		}
+		buf, err = Append(buf, num, 0, 10)
+	}
+	if err != nil {
+		return buf, err
+	}
+	return append(buf, unit.Suffix()...), nil
+}
+
+/*
+Multiplies an amount by a scaled decimal rate, such as a fee rate from
+`ParseRate`, and rounds the result to the precision of the amount according to
+the mode. For example, applying "2.9%" at frac 4 (290) to 12.34 at frac 2
+(1234) produces 35.786 cents, which is 36 with `RoundHalfUp` and 35 with
+`RoundDown`, while `RoundExact` returns an error wrapping `ErrPrecision`.
+
+The product is computed exactly in 128 bits, so intermediate results never
+overflow; only a final result outside `int64` is an error, wrapping
+`ErrRange`. The rate precision is limited to 19.
+*/
+func ApplyRate(amount int64, rate int64, rateFrac uint, mode RoundingMode) (int64, error) {
+	err := mode.validate()
+	if err != nil {
+		return 0, fmt.Errorf(`unable to apply rate %v to %v: %w`, rate, amount, err)
+	}
+
+	pow, ok := powUint(10, rateFrac)
+	if !ok {
+		return 0, fmt.Errorf(
+			`unable to apply rate %v to %v: rate precision %v exceeds limit %v`,
+			rate, amount, rateFrac, len(pows[10])-1,
+		)
+	}
+
+	out, _, err := applyRate(amount, rate, rateFrac, pow, mode)
+	return out, err
+}
+
+// Must be called with a valid mode and `pow == 10^rateFrac`.
+func applyRate(amount int64, rate int64, rateFrac uint, pow uint64, mode RoundingMode) (int64, residual, error) {
+	out, res, code := mulDivRes(amount, rate, pow, mode)
+	switch code {
+	case failNone:
+		return out, res, nil
+	case failExponent:
+		return 0, res, errorf(
+			ErrPrecision,
+			`unable to apply rate %v (fraction %v) to %v: result requires rounding`,
+			rate, rateFrac, amount,
+		)
+	default:
+		return 0, res, errorf(ErrRange, `unable to apply rate %v (fraction %v) to %v: overflow of int64`, rate, rateFrac, amount)
+	}
+}
@@ -0,0 +1,160 @@
+package frac
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseRate(*testing.T) {
+	testParseRate(`2.9%`, 4, 290)
+	testParseRate(`29‰`, 4, 290)
+	testParseRate(`290bps`, 4, 290)
+	testParseRate(`290 bps`, 4, 290)
+	testParseRate(`0.029`, 4, 290)
+	testParseRate(`25bps`, 4, 25)
+	testParseRate(`-1.5%`, 4, -150)
+	testParseRate(`100%`, 2, 1_00)
+	testParseRate(`100%`, 0, 1)
+	testParseRate(`250%`, 1, 2_5)
+	testParseRate(`1000bps`, 1, 1)
+	testParseRate(`0%`, 0, 0)
+	testParseRate(`2.95%`, 18, 29_500_000_000_000_000)
+
+	testParseRateErr(`2.95%`, 3, `exponent exceeds`, ErrPrecision)
+	testParseRateErr(`2.9%`, 2, `exponent exceeds`, ErrPrecision)
+	testParseRateErr(`25bps`, 3, `non-zero digits exceed target precision`, ErrPrecision)
+	testParseRateErr(`1%`, 1, `non-zero digits exceed target precision`, ErrPrecision)
+	testParseRateErr(`%`, 4, `non-digit character '%'`, ErrSyntax)
+	testParseRateErr(`bps`, 4, `non-digit character 'b'`, ErrSyntax)
+	testParseRateErr(` %`, 4, `empty input`, ErrSyntax)
+	testParseRateErr(`2.9%%`, 4, `non-digit character '%'`, ErrSyntax)
+	testParseRateErr(`2.9 `, 4, `non-digit character ' '`, ErrSyntax)
+	testParseRateErr(`2.9BPS`, 4, `non-digit character 'B'`, ErrSyntax)
+	testParseRateErr(`100000%`, 18, `overflow`, ErrRange)
+}
+
+func TestFormatRate(*testing.T) {
+	testFormatRate(290, 4, RatePercent, `2.9%`)
+	testFormatRate(290, 4, RatePermille, `29‰`)
+	testFormatRate(290, 4, RateBps, `290bps`)
+	testFormatRate(290, 4, RateRatio, `0.029`)
+	testFormatRate(-150, 4, RatePercent, `-1.5%`)
+	testFormatRate(1, 0, RatePercent, `100%`)
+	testFormatRate(2_5, 1, RateBps, `25000bps`)
+	testFormatRate(0, 0, RateBps, `0bps`)
+
+	_, err := FormatRate(math.MaxInt64, 0, RatePercent)
+	if !errors.Is(err, ErrRange) {
+		panic(fmt.Errorf(`expected ErrRange, got %v`, err))
+	}
+	_, err = FormatRate(1, 0, RateBps+1)
+	if err == nil || !strings.Contains(err.Error(), `unsupported rate unit`) {
+		panic(fmt.Errorf(`expected unsupported unit error, got %v`, err))
+	}
+	_, err = FormatRate(1, 70, RatePercent)
+	if err == nil || !strings.Contains(err.Error(), `exceeds limit`) {
+		panic(fmt.Errorf(`expected precision limit error, got %v`, err))
+	}
+}
+
+func TestApplyRate(*testing.T) {
+	testApplyRate(12_34, 290, 4, RoundHalfUp, 36)
+	testApplyRate(12_34, 290, 4, RoundDown, 35)
+	testApplyRate(12_00, 250, 4, RoundExact, 30)
+	testApplyRate(-12_34, 290, 4, RoundHalfUp, -36)
+	testApplyRate(12_34, -290, 4, RoundFloor, -36)
+	testApplyRate(0, 290, 4, RoundExact, 0)
+	testApplyRate(12_34, 0, 4, RoundExact, 0)
+	testApplyRate(12_34, 1, 0, RoundExact, 12_34)
+	testApplyRate(math.MaxInt64, 1_0000, 4, RoundExact, math.MaxInt64)
+	testApplyRate(math.MinInt64, 1_0000, 4, RoundExact, math.MinInt64)
+	testApplyRate(math.MinInt64, -1, 19, RoundCeil, 1)
+
+	testApplyRateErr(12_34, 290, 4, RoundExact, `result requires rounding`, ErrPrecision)
+	testApplyRateErr(math.MaxInt64, 2, 0, RoundExact, `overflow of int64`, ErrRange)
+	testApplyRateErr(math.MinInt64, -1_0000, 4, RoundExact, `overflow of int64`, ErrRange)
+	testApplyRateErr(math.MaxInt64, math.MaxInt64, 18, RoundDown, `overflow of int64`, ErrRange)
+	testApplyRateErr(1, 1, 20, RoundDown, `rate precision 20 exceeds limit 19`, nil)
+	testApplyRateErr(1, 1, 0, RoundHalfEven+1, `unsupported rounding mode RoundingMode(8)`, nil)
+}
+
+func TestRoundingModes(*testing.T) {
+	modes := []RoundingMode{RoundUp, RoundDown, RoundCeil, RoundFloor, RoundHalfUp, RoundHalfDown, RoundHalfEven}
+
+	for _, row := range []struct {
+		src int64
+		exp []int64
+	}{
+		{5_5, []int64{6, 5, 6, 5, 6, 5, 6}},
+		{2_5, []int64{3, 2, 3, 2, 3, 2, 2}},
+		{1_6, []int64{2, 1, 2, 1, 2, 2, 2}},
+		{1_1, []int64{2, 1, 2, 1, 1, 1, 1}},
+		{1_0, []int64{1, 1, 1, 1, 1, 1, 1}},
+		{-1_0, []int64{-1, -1, -1, -1, -1, -1, -1}},
+		{-1_1, []int64{-2, -1, -1, -2, -1, -1, -1}},
+		{-1_6, []int64{-2, -1, -1, -2, -2, -2, -2}},
+		{-2_5, []int64{-3, -2, -2, -3, -3, -2, -2}},
+		{-5_5, []int64{-6, -5, -5, -6, -6, -5, -6}},
+	} {
+		for ind, mode := range modes {
+			testApplyRate(row.src, 1, 1, mode, row.exp[ind])
+		}
+	}
+
+	testEqual(RoundHalfEven.String(), `RoundHalfEven`)
+	testEqual(RoundExact.String(), `RoundExact`)
+}
+
+func testParseRate(src string, frac uint, exp int64) {
+	act, err := ParseRate(src, frac)
+	if err != nil {
+		panic(fmt.Errorf(`failed to parse rate %q (frac %v): %+v`, src, frac, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected to parse rate %q (frac %v) into %v, got %v`, src, frac, exp, act))
+	}
+}
+
+func testParseRateErr(src string, frac uint, msg string, kind error) {
+	res, err := ParseRate(src, frac)
+	if err == nil {
+		panic(fmt.Errorf(`expected parsing rate %q (frac %v) to fail; instead got %v`, src, frac, res))
+	}
+	if !strings.Contains(err.Error(), msg) || !errors.Is(err, kind) {
+		panic(fmt.Errorf(`expected error from parsing rate %q (frac %v) to be %q containing %q, got %q`, src, frac, kind, msg, err))
+	}
+}
+
+func testFormatRate(rate int64, frac uint, unit RateUnit, exp string) {
+	act, err := FormatRate(rate, frac, unit)
+	if err != nil {
+		panic(fmt.Errorf(`failed to format rate %v (frac %v): %+v`, rate, frac, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected to format rate %v (frac %v) as %q, got %q`, rate, frac, exp, act))
+	}
+	testParseRate(act, frac, rate)
+}
+
+func testApplyRate(amount int64, rate int64, rateFrac uint, mode RoundingMode, exp int64) {
+	act, err := ApplyRate(amount, rate, rateFrac, mode)
+	if err != nil {
+		panic(fmt.Errorf(`failed to apply rate %v (frac %v) to %v with %v: %+v`, rate, rateFrac, amount, mode, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected applying rate %v (frac %v) to %v with %v to produce %v, got %v`, rate, rateFrac, amount, mode, exp, act))
+	}
+}
+
+func testApplyRateErr(amount int64, rate int64, rateFrac uint, mode RoundingMode, msg string, kind error) {
+	res, err := ApplyRate(amount, rate, rateFrac, mode)
+	if err == nil {
+		panic(fmt.Errorf(`expected applying rate %v (frac %v) to %v to fail; instead got %v`, rate, rateFrac, amount, res))
+	}
+	if !strings.Contains(err.Error(), msg) || (kind != nil && !errors.Is(err, kind)) {
+		panic(fmt.Errorf(`expected error from applying rate %v (frac %v) to %v to contain %q, got %q`, rate, rateFrac, amount, msg, err))
+	}
+}
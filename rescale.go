@@ -0,0 +1,59 @@
+package frac
+
+/*
+Converts a scaled integer between fractional precisions of the same radix,
+without rounding. For example, in radix 10, `Rescale(12_50, 2, 4, 10)` is
+12_5000, and `Rescale(12_5000, 4, 2, 10)` is 12_50. Useful for migrating
+stored amounts to a different precision.
+
+Returns an error wrapping `ErrPrecision` when reducing the precision would drop
+non-zero digits, such as for `Rescale(12_5050, 4, 2, 10)`, and `ErrRange` when
+increasing the precision overflows `int64`. Supports radixes up to 64, matching
+`Alphabet`.
+*/
+func Rescale(num int64, from uint, to uint, radix uint) (int64, error) {
+	if !validRadixAny(radix) {
+		return 0, errorf(ErrRadix, `unable to rescale %v: unsupported radix %v`, num, radix)
+	}
+
+	if to >= from {
+		out, code := scale(num, radix, to-from)
+		if code != failNone {
+			return 0, errorf(
+				ErrRange,
+				`unable to rescale %v from fraction %v to %v (radix %v): %v of int64`,
+				num, from, to, radix, rangeName(code),
+			)
+		}
+		return out, nil
+	}
+
+	if num == 0 {
+		return 0, nil
+	}
+
+	// A power that doesn't fit into `uint64` exceeds every non-zero magnitude,
+	// which therefore can't be divisible by it.
+	pow, ok := powUint(radix, from-to)
+	mag := magnitude(num)
+	if !ok || mag%pow != 0 {
+		return 0, errorf(
+			ErrPrecision,
+			`unable to rescale %v from fraction %v to %v (radix %v): non-zero digits exceed target precision`,
+			num, from, to, radix,
+		)
+	}
+
+	mag /= pow
+	if num < 0 {
+		return -int64(mag), nil
+	}
+	return int64(mag), nil
+}
+
+func rangeName(code parseCode) string {
+	if code == failUnderflow {
+		return `underflow`
+	}
+	return `overflow`
+}
@@ -0,0 +1,52 @@
+package frac
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRescale(*testing.T) {
+	testRescale(12_50, 2, 4, 10, 12_5000)
+	testRescale(-12_50, 2, 4, 10, -12_5000)
+	testRescale(12_5000, 4, 2, 10, 12_50)
+	testRescale(-12_5000, 4, 2, 10, -12_50)
+	testRescale(12_50, 2, 2, 10, 12_50)
+	testRescale(0, 100, 0, 10, 0)
+	testRescale(0, 0, 100, 10, 0)
+	testRescale(0b1_1, 1, 3, 2, 0b1_100)
+	testRescale(math.MinInt64, 63, 0, 2, -1)
+	testRescale(math.MinInt64, 0, 0, 10, math.MinInt64)
+	testRescale(-922_337_203_685_477_580, 0, 1, 10, -9_223_372_036_854_775_800)
+	testRescale(math.MaxInt64-7, 1, 0, 10, 922_337_203_685_477_580)
+
+	testRescaleErr(12_5050, 4, 2, 10, ErrPrecision, `non-zero digits exceed target precision`)
+	testRescaleErr(1, 100, 0, 10, ErrPrecision, `non-zero digits exceed target precision`)
+	testRescaleErr(1, 0, 19, 10, ErrRange, `overflow of int64`)
+	testRescaleErr(-1, 0, 100, 10, ErrRange, `underflow of int64`)
+	testRescaleErr(math.MaxInt64, 0, 1, 10, ErrRange, `overflow of int64`)
+	testRescaleErr(math.MaxInt64, 1, 0, 10, ErrPrecision, `non-zero digits exceed target precision`)
+	testRescaleErr(1, 0, 1, 65, ErrRadix, `unsupported radix 65`)
+}
+
+func testRescale(num int64, from, to, radix uint, exp int64) {
+	act, err := Rescale(num, from, to, radix)
+	if err != nil {
+		panic(fmt.Errorf(`failed to rescale %v from %v to %v (radix %v): %+v`, num, from, to, radix, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected rescaling %v from %v to %v (radix %v) to produce %v, got %v`, num, from, to, radix, exp, act))
+	}
+}
+
+func testRescaleErr(num int64, from, to, radix uint, kind error, msg string) {
+	res, err := Rescale(num, from, to, radix)
+	if err == nil {
+		panic(fmt.Errorf(`expected rescaling %v from %v to %v (radix %v) to fail; instead got %v`, num, from, to, radix, res))
+	}
+	if !errors.Is(err, kind) || !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from rescaling %v from %v to %v (radix %v) to be %q containing %q, got %q`, num, from, to, radix, kind, msg, err))
+	}
+}
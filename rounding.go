@@ -0,0 +1,235 @@
+package frac
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+/*
+Describes how to round a result that can't be represented exactly. The zero
+value `RoundExact` matches the rest of the package: inexact results are
+rejected with an error wrapping `ErrPrecision` rather than rounded.
+*/
+type RoundingMode byte
+
+const (
+	// Reject inexact results with an error wrapping `ErrPrecision`.
+	RoundExact RoundingMode = iota
+
+	// Round toward zero, truncating: 1.5 -> 1, -1.5 -> -1.
+	RoundDown
+
+	// Round away from zero: 1.1 -> 2, -1.1 -> -2.
+	RoundUp
+
+	// Round toward negative infinity: 1.5 -> 1, -1.5 -> -2.
+	RoundFloor
+
+	// Round toward positive infinity: 1.5 -> 2, -1.5 -> -1.
+	RoundCeil
+
+	// Round to nearest, ties away from zero: 2.5 -> 3, -2.5 -> -3.
+	RoundHalfUp
+
+	// Round to nearest, ties toward zero: 2.5 -> 2, -2.5 -> -2.
+	RoundHalfDown
+
+	// Round to nearest, ties to even, also known as banker's rounding:
+	// 2.5 -> 2, 3.5 -> 4.
+	RoundHalfEven
+)
+
+// Implement `fmt.Stringer`.
+func (self RoundingMode) String() string {
+	switch self {
+	case RoundExact:
+		return `RoundExact`
+	case RoundDown:
+		return `RoundDown`
+	case RoundUp:
+		return `RoundUp`
+	case RoundFloor:
+		return `RoundFloor`
+	case RoundCeil:
+		return `RoundCeil`
+	case RoundHalfUp:
+		return `RoundHalfUp`
+	case RoundHalfDown:
+		return `RoundHalfDown`
+	case RoundHalfEven:
+		return `RoundHalfEven`
+	default:
+		return fmt.Sprintf(`RoundingMode(%d)`, byte(self))
+	}
+}
+
+func (self RoundingMode) validate() error {
+	if !(self <= RoundHalfEven) {
+		return fmt.Errorf(`unsupported rounding mode %v`, self)
+	}
+	return nil
+}
+
+/*
+Decides whether the magnitude quotient `quo`, with the remainder `rem` of the
+division by `div`, must be incremented to round it according to the mode. `neg`
+is the sign of the exact result. Reports false for `RoundExact`, which must be
+handled by the caller. Requires `rem < div`.
+*/
+func (self RoundingMode) roundUp(neg bool, quo, rem, div uint64) bool {
+	if rem == 0 {
+		return false
+	}
+
+	// Compares `rem` with `div - rem` rather than `2 * rem` with `div`,
+	// which could overflow.
+	half := cmpUint(rem, div-rem)
+
+	switch self {
+	case RoundUp:
+		return true
+	case RoundFloor:
+		return neg
+	case RoundCeil:
+		return !neg
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfDown:
+		return half > 0
+	case RoundHalfEven:
+		return half > 0 || (half == 0 && quo%2 == 1)
+	default:
+		return false
+	}
+}
+
+/*
+Rounds the number to a multiple of the increment according to the mode,
+returning the rounded number and the rounding adjustment: the rounded number
+minus the original. Useful for cash rounding: Swiss francs at frac 2 are
+rounded to 0.05 with the increment 5, so 12.34 becomes 12.35 with the
+adjustment 0.01, or 1 in minor units. Recording the adjustment allows
+reconciling rounded totals.
+
+The increment must be positive. With `RoundExact`, a number that isn't a
+multiple of the increment is rejected with an error wrapping `ErrPrecision`.
+A result outside `int64` is rejected with an error wrapping `ErrRange`.
+*/
+func RoundTo(num int64, increment int64, mode RoundingMode) (rounded int64, adj int64, err error) {
+	err = mode.validate()
+	if err != nil {
+		return 0, 0, fmt.Errorf(`unable to round %v to %v: %w`, num, increment, err)
+	}
+	if increment <= 0 {
+		return 0, 0, fmt.Errorf(`unable to round %v to %v: increment must be positive`, num, increment)
+	}
+
+	neg := num < 0
+	inc := uint64(increment)
+	quo, rem := bits.Div64(0, magnitude(num), inc)
+
+	if rem != 0 && mode == RoundExact {
+		return 0, 0, errorf(ErrPrecision, `unable to round %v to %v: number is not a multiple of increment`, num, increment)
+	}
+	if mode.roundUp(neg, quo, rem, inc) {
+		quo++
+	}
+
+	hi, lo := bits.Mul64(quo, inc)
+	rounded, ok := fromMagnitude(lo, neg)
+	if hi != 0 || !ok {
+		return 0, 0, errorf(ErrRange, `unable to round %v to %v: overflow of int64`, num, increment)
+	}
+	return rounded, rounded - num, nil
+}
+
+/*
+Computes `a * b / div` exactly in 128 bits, rounding according to the mode.
+Reports `failExponent` for inexact results with `RoundExact`, and
+`failOverflow` or `failUnderflow` when the result doesn't fit into
+`int64`. Requires a valid mode and a non-zero divisor.
+*/
+func mulDiv(a int64, b int64, div uint64, mode RoundingMode) (int64, parseCode) {
+	out, _, code := mulDivRes(a, b, div, mode)
+	return out, code
+}
+
+// Same as `mulDiv` but also returns the residual of rounding; see `residual`.
+func mulDivRes(a int64, b int64, div uint64, mode RoundingMode) (int64, residual, parseCode) {
+	neg := (a < 0) != (b < 0)
+	hi, lo := bits.Mul64(magnitude(a), magnitude(b))
+	if hi >= div {
+		return 0, residual{}, rangeCode(neg)
+	}
+
+	quo, rem := bits.Div64(hi, lo, div)
+	if rem != 0 && mode == RoundExact {
+		return 0, residual{}, failExponent
+	}
+
+	// Rounding toward zero leaves the result short of `a * b / div` by the
+	// remainder, while rounding away from zero overshoots it by the rest.
+	res := residual{mag: rem, neg: !neg, div: div}
+	if mode.roundUp(neg, quo, rem, div) {
+		if quo == math.MaxUint64 {
+			return 0, residual{}, rangeCode(neg)
+		}
+		quo++
+		res.mag, res.neg = div-rem, neg
+	}
+
+	out, ok := fromMagnitude(quo, neg)
+	if !ok {
+		return 0, residual{}, rangeCode(neg)
+	}
+	return out, res, failNone
+}
+
+/*
+Rounding error of a division by `div`: the rounded result minus the exact one,
+in units of `1/div` of the result. Its magnitude is below `div`.
+*/
+type residual struct {
+	mag uint64
+	neg bool
+	div uint64
+}
+
+/*
+Returns the residual in units of `1/pow` of the result, truncated toward zero.
+Requires `pow` to fit into `int64`.
+*/
+func (self residual) scale(pow uint64) int64 {
+	if self.mag == 0 {
+		return 0
+	}
+	hi, lo := bits.Mul64(self.mag, pow)
+	quo, _ := bits.Div64(hi, lo, self.div)
+	out, _ := fromMagnitude(quo, self.neg)
+	return out
+}
+
+func rangeCode(neg bool) parseCode {
+	if neg {
+		return failUnderflow
+	}
+	return failOverflow
+}
+
+/*
+Inverse of `magnitude`: applies the sign to the magnitude, reporting false when
+the result doesn't fit into `int64`. A zero magnitude is always non-negative.
+*/
+func fromMagnitude(mag uint64, neg bool) (int64, bool) {
+	if neg {
+		if mag > -math.MinInt64 {
+			return 0, false
+		}
+		return -int64(mag), true
+	}
+	if mag > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(mag), true
+}
@@ -0,0 +1,128 @@
+package frac
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+/*
+Returns the sum of the numbers, which must have the same fractional precision,
+or an error wrapping `ErrRange` when the sum doesn't fit into `int64`.
+Intermediate sums may exceed `int64` as long as the total fits; see
+`Accumulator`.
+*/
+func Sum(nums []int64) (int64, error) {
+	var acc Accumulator
+	acc.AddAll(nums)
+	return acc.Result()
+}
+
+/*
+Returns the arithmetic mean of the numbers, which must have the same fractional
+precision, rounded to that precision according to the mode. The mean is exact:
+the sum is computed in 128 bits and never overflows. Returns an error for an
+empty slice, and with `RoundExact`, an error wrapping `ErrPrecision` when the
+mean isn't representable without rounding.
+*/
+func Mean(nums []int64, mode RoundingMode) (int64, error) {
+	var acc Accumulator
+	acc.AddAll(nums)
+	return acc.Mean(mode)
+}
+
+/*
+Streaming sum of scaled integers, which must have the same fractional
+precision. The running sum is widened to 128 bits, so adding never overflows
+and never loses information; overflow of `int64` is reported only by `Result`,
+and only when the final sum doesn't fit. This allows summing millions of
+amounts, including mixed signs, without silent wraparound. The zero value is
+an empty accumulator ready to use.
+
+The 128-bit sum itself can only overflow after more than 2^64 additions.
+*/
+type Accumulator struct {
+	hi    int64
+	lo    uint64
+	count uint64
+}
+
+// Adds the number to the running sum.
+func (self *Accumulator) Add(num int64) {
+	var carry uint64
+	self.lo, carry = bits.Add64(self.lo, uint64(num), 0)
+	self.hi += int64(carry)
+	if num < 0 {
+		self.hi--
+	}
+	self.count++
+}
+
+// Adds each number to the running sum.
+func (self *Accumulator) AddAll(nums []int64) {
+	for _, num := range nums {
+		self.Add(num)
+	}
+}
+
+// Returns the amount of added numbers.
+func (self Accumulator) Count() uint64 { return self.count }
+
+// Resets the accumulator to the empty state.
+func (self *Accumulator) Reset() { *self = Accumulator{} }
+
+/*
+Returns the sum, or an error wrapping `ErrRange` when it doesn't fit into
+`int64`. The accumulator is unaffected, so adding may continue; for example,
+later negative amounts may bring the sum back into range.
+*/
+func (self Accumulator) Result() (int64, error) {
+	hi, lo, neg := self.magnitude()
+	out, ok := fromMagnitude(lo, neg)
+	if hi != 0 || !ok {
+		return 0, errorf(ErrRange, `unable to sum %v numbers: %v of int64`, self.count, rangeName(rangeCode(neg)))
+	}
+	return out, nil
+}
+
+/*
+Returns the arithmetic mean of the added numbers, rounded to their precision
+according to the mode. See `Mean`.
+*/
+func (self Accumulator) Mean(mode RoundingMode) (int64, error) {
+	err := mode.validate()
+	if err != nil {
+		return 0, fmt.Errorf(`unable to compute mean: %w`, err)
+	}
+	if self.count == 0 {
+		return 0, fmt.Errorf(`unable to compute mean: no numbers`)
+	}
+
+	// The magnitude of a sum of N numbers is at most N * 2^63, so the high
+	// half is below N, which is required by `bits.Div64`.
+	hi, lo, neg := self.magnitude()
+	quo, rem := bits.Div64(hi, lo, self.count)
+
+	if rem != 0 && mode == RoundExact {
+		return 0, errorf(ErrPrecision, `unable to compute mean of %v numbers: result requires rounding`, self.count)
+	}
+	if mode.roundUp(neg, quo, rem, self.count) {
+		quo++
+	}
+
+	// The mean is between the smallest and largest numbers, so it fits.
+	out, _ := fromMagnitude(quo, neg)
+	return out, nil
+}
+
+// Returns the magnitude of the 128-bit sum as two halves, and its sign.
+func (self Accumulator) magnitude() (hi uint64, lo uint64, neg bool) {
+	hi, lo = uint64(self.hi), self.lo
+	if self.hi >= 0 {
+		return hi, lo, false
+	}
+
+	var borrow uint64
+	lo, borrow = bits.Sub64(0, lo, 0)
+	hi, _ = bits.Sub64(0, hi, borrow)
+	return hi, lo, true
+}
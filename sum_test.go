@@ -0,0 +1,121 @@
+package frac
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSum(*testing.T) {
+	testSum(nil, 0)
+	testSum([]int64{12_34, -2_34, 0}, 10_00)
+	testSum([]int64{math.MaxInt64, 1, -1}, math.MaxInt64)
+	testSum([]int64{math.MaxInt64, math.MaxInt64, math.MinInt64, math.MinInt64, math.MaxInt64}, math.MaxInt64-2)
+	testSum([]int64{math.MinInt64, math.MinInt64, math.MaxInt64, math.MaxInt64}, -2)
+	testSum([]int64{math.MinInt64}, math.MinInt64)
+	testSum([]int64{math.MinInt64, -1, 1}, math.MinInt64)
+
+	testSumErr([]int64{math.MaxInt64, 1}, `unable to sum 2 numbers: overflow of int64`)
+	testSumErr([]int64{math.MinInt64, -1}, `unable to sum 2 numbers: underflow of int64`)
+	testSumErr([]int64{math.MinInt64, math.MinInt64, math.MinInt64}, `underflow of int64`)
+}
+
+func TestAccumulator(*testing.T) {
+	var acc Accumulator
+	for range counter(1000) {
+		acc.Add(math.MaxInt64)
+	}
+	testEqual(acc.Count(), uint64(1000))
+
+	_, err := acc.Result()
+	if !errors.Is(err, ErrRange) {
+		panic(fmt.Errorf(`expected ErrRange, got %v`, err))
+	}
+
+	mean, err := acc.Mean(RoundExact)
+	if err != nil {
+		panic(err)
+	}
+	testEqual(mean, int64(math.MaxInt64))
+
+	for range counter(999) {
+		acc.Add(-math.MaxInt64)
+	}
+	res, err := acc.Result()
+	if err != nil {
+		panic(err)
+	}
+	testEqual(res, int64(math.MaxInt64))
+
+	acc.Reset()
+	testEqual(acc, Accumulator{})
+	for range counter(1000) {
+		acc.Add(math.MinInt64)
+	}
+	mean, err = acc.Mean(RoundExact)
+	if err != nil {
+		panic(err)
+	}
+	testEqual(mean, int64(math.MinInt64))
+}
+
+func TestMean(*testing.T) {
+	testMean([]int64{1_00, 2_00, 4_00}, RoundHalfUp, 2_33)
+	testMean([]int64{1_00, 2_00, 4_00}, RoundUp, 2_34)
+	testMean([]int64{-1_00, -2_00, -4_00}, RoundHalfUp, -2_33)
+	testMean([]int64{-1_00, -2_00, -4_00}, RoundFloor, -2_34)
+	testMean([]int64{1, 2}, RoundHalfEven, 2)
+	testMean([]int64{3, 4}, RoundHalfEven, 4)
+	testMean([]int64{-1, -2}, RoundHalfUp, -2)
+	testMean([]int64{-1, -2}, RoundHalfDown, -1)
+	testMean([]int64{2, 4, 6}, RoundExact, 4)
+	testMean([]int64{math.MaxInt64, math.MaxInt64 - 1}, RoundDown, math.MaxInt64-1)
+	testMean([]int64{math.MaxInt64, math.MinInt64}, RoundFloor, -1)
+	testMean([]int64{math.MinInt64, math.MinInt64 + 1}, RoundFloor, math.MinInt64)
+
+	testMeanErr(nil, RoundDown, `no numbers`)
+	testMeanErr([]int64{1, 2}, RoundExact, `result requires rounding`)
+	testMeanErr([]int64{1, 2}, RoundHalfEven+1, `unsupported rounding mode`)
+}
+
+func testSum(nums []int64, exp int64) {
+	act, err := Sum(nums)
+	if err != nil {
+		panic(fmt.Errorf(`failed to sum %v: %+v`, nums, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected sum of %v to be %v, got %v`, nums, exp, act))
+	}
+}
+
+func testSumErr(nums []int64, msg string) {
+	res, err := Sum(nums)
+	if err == nil {
+		panic(fmt.Errorf(`expected sum of %v to fail; instead got %v`, nums, res))
+	}
+	if !strings.Contains(err.Error(), msg) || !errors.Is(err, ErrRange) {
+		panic(fmt.Errorf(`expected error from sum of %v to contain %q, got %q`, nums, msg, err))
+	}
+}
+
+func testMean(nums []int64, mode RoundingMode, exp int64) {
+	act, err := Mean(nums, mode)
+	if err != nil {
+		panic(fmt.Errorf(`failed to compute mean of %v with %v: %+v`, nums, mode, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected mean of %v with %v to be %v, got %v`, nums, mode, exp, act))
+	}
+}
+
+func testMeanErr(nums []int64, mode RoundingMode, msg string) {
+	res, err := Mean(nums, mode)
+	if err == nil {
+		panic(fmt.Errorf(`expected mean of %v to fail; instead got %v`, nums, res))
+	}
+	if !strings.Contains(err.Error(), msg) {
+		panic(fmt.Errorf(`expected error from mean of %v to contain %q, got %q`, nums, msg, err))
+	}
+}
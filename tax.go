@@ -0,0 +1,180 @@
+package frac
+
+import "fmt"
+
+/*
+Result of a tax computation, in the minor units of the amounts, such as cents.
+`Gross` is always `Net + Tax`.
+
+`Adj` is the rounding adjustment for audit: the rounded tax minus the exact
+tax, at the precision of the amounts plus the precision of the rate. For
+example, with amounts in cents and a rate at frac 4, `Adj` is in units of
+0.0001 cents, and a tax of 246.8 cents rounded to 247 has the adjustment 2000.
+When the exact tax has more digits, the adjustment is truncated toward zero.
+*/
+type TaxSplit struct {
+	Net   int64
+	Tax   int64
+	Gross int64
+	Adj   int64
+}
+
+/*
+Computes tax on a tax-exclusive (net) amount, rounding the tax to the precision
+of the amount according to the mode. The rate is a scaled decimal ratio, such
+as from `ParseRate`. For example, 20% VAT at frac 4 (2000) on 12.34 at frac 2
+(1234) is 2.468, which is 247 with `RoundHalfUp`, giving a gross amount of
+1481 and the adjustment 2000 (0.002 at frac 6).
+
+The rate precision is limited to 18.
+*/
+func TaxExclusive(net int64, rate int64, rateFrac uint, mode RoundingMode) (TaxSplit, error) {
+	tax, adj, err := applyRateAdj(net, rate, rateFrac, mode)
+	if err != nil {
+		return TaxSplit{}, fmt.Errorf(`unable to compute tax on %v: %w`, net, err)
+	}
+
+	gross, ok := addInt(net, tax)
+	if !ok {
+		return TaxSplit{}, errorf(ErrRange, `unable to compute tax on %v: overflow of int64`, net)
+	}
+	return TaxSplit{Net: net, Tax: tax, Gross: gross, Adj: adj}, nil
+}
+
+/*
+Extracts tax from a tax-inclusive (gross) amount, computing the tax as
+`gross * rate / (1 + rate)` exactly in 128 bits and rounding it to the
+precision of the amount according to the mode. The net amount is the
+remainder, so that `Net + Tax == Gross` always holds. For example, 20% VAT at
+frac 4 (2000) included in 14.81 at frac 2 (1481) is 2.468333..., which is 247
+with `RoundHalfUp`, leaving 1234, with the adjustment 1666 (0.001666 at frac 6,
+truncated).
+
+The rate must be non-negative, and its precision is limited to 18.
+*/
+func TaxInclusive(gross int64, rate int64, rateFrac uint, mode RoundingMode) (TaxSplit, error) {
+	err := mode.validate()
+	if err != nil {
+		return TaxSplit{}, fmt.Errorf(`unable to extract tax from %v: %w`, gross, err)
+	}
+	if rate < 0 {
+		return TaxSplit{}, fmt.Errorf(`unable to extract tax from %v: negative rate %v`, gross, rate)
+	}
+
+	pow, err := Pow(10, rateFrac)
+	if err != nil {
+		return TaxSplit{}, fmt.Errorf(`unable to extract tax from %v: rate precision %v exceeds limit 18`, gross, rateFrac)
+	}
+
+	// Both are below 2^63, so the sum fits into `uint64`.
+	den := uint64(pow) + uint64(rate)
+
+	tax, res, code := mulDivRes(gross, rate, den, mode)
+	if code == failExponent {
+		return TaxSplit{}, errorf(
+			ErrPrecision,
+			`unable to extract tax at rate %v (fraction %v) from %v: result requires rounding`,
+			rate, rateFrac, gross,
+		)
+	}
+
+	// The tax never exceeds the gross amount in magnitude, and has the same
+	// sign, so neither the tax nor the net amount can overflow.
+	return TaxSplit{Net: gross - tax, Tax: tax, Gross: gross, Adj: res.scale(uint64(pow))}, nil
+}
+
+/*
+Computes tax on each tax-exclusive line amount, rounding per line as required
+in some jurisdictions, and returns the totals, where `Adj` is the total of the
+per-line adjustments. Also returns the adjustment for audit: the total of the
+rounded per-line taxes minus the tax computed on the total net amount, which
+is how other jurisdictions round (per invoice). For per-invoice rounding, use
+`TaxExclusive` on the total instead.
+*/
+func TaxPerLine(nets []int64, rate int64, rateFrac uint, mode RoundingMode) (total TaxSplit, adj int64, err error) {
+	var ok bool
+
+	for _, net := range nets {
+		line, err := TaxExclusive(net, rate, rateFrac, mode)
+		if err != nil {
+			return TaxSplit{}, 0, err
+		}
+
+		total.Net, ok = addInt(total.Net, line.Net)
+		if ok {
+			total.Tax, ok = addInt(total.Tax, line.Tax)
+		}
+		if ok {
+			total.Gross, ok = addInt(total.Gross, line.Gross)
+		}
+		if ok {
+			total.Adj, ok = addInt(total.Adj, line.Adj)
+		}
+		if !ok {
+			return TaxSplit{}, 0, errorf(ErrRange, `unable to compute tax per line: overflow of int64`)
+		}
+	}
+
+	invoice, err := TaxExclusive(total.Net, rate, rateFrac, mode)
+	if err != nil {
+		return TaxSplit{}, 0, err
+	}
+
+	return total, total.Tax - invoice.Tax, nil
+}
+
+/*
+Applies a discount rate to an amount, such as "15%" at frac 4 (1500) from
+`ParseRate`. Rounds the discount to the precision of the amount according to
+the mode, and returns both the discounted amount and the discount, so that
+their sum is the original amount. Also returns the rounding adjustment of the
+discount, like `TaxSplit.Adj`: 15% of 19.99 is 2.9985, which is 300 cents with
+`RoundHalfUp`, with the adjustment 1500 (0.0015 at frac 6).
+
+The rate precision is limited to 18.
+*/
+func Discount(amount int64, rate int64, rateFrac uint, mode RoundingMode) (discounted int64, discount int64, adj int64, err error) {
+	discount, adj, err = applyRateAdj(amount, rate, rateFrac, mode)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf(`unable to discount %v: %w`, amount, err)
+	}
+
+	discounted, ok := subInt(amount, discount)
+	if !ok {
+		return 0, 0, 0, errorf(ErrRange, `unable to discount %v by %v: overflow of int64`, amount, discount)
+	}
+	return discounted, discount, adj, nil
+}
+
+/*
+Same as `ApplyRate` but also returns the rounding adjustment at the precision
+of the amount plus `rateFrac`; see `TaxSplit.Adj`. The rate precision is
+limited to 18, so that the adjustment fits into `int64`.
+*/
+func applyRateAdj(amount int64, rate int64, rateFrac uint, mode RoundingMode) (int64, int64, error) {
+	err := mode.validate()
+	if err != nil {
+		return 0, 0, fmt.Errorf(`unable to apply rate %v to %v: %w`, rate, amount, err)
+	}
+
+	pow, err := Pow(10, rateFrac)
+	if err != nil {
+		return 0, 0, fmt.Errorf(`unable to apply rate %v to %v: rate precision %v exceeds limit 18`, rate, amount, rateFrac)
+	}
+
+	out, res, err := applyRate(amount, rate, rateFrac, uint64(pow), mode)
+	if err != nil {
+		return 0, 0, err
+	}
+	return out, res.scale(uint64(pow)), nil
+}
+
+func addInt(one, two int64) (int64, bool) {
+	out := one + two
+	return out, (out > one) == (two > 0)
+}
+
+func subInt(one, two int64) (int64, bool) {
+	out := one - two
+	return out, (out < one) == (two > 0)
+}
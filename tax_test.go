@@ -0,0 +1,169 @@
+package frac
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTaxExclusive(*testing.T) {
+	testTax(TaxExclusive, 12_34, 2000, 4, RoundHalfUp, TaxSplit{12_34, 2_47, 14_81, 2000})
+	testTax(TaxExclusive, 12_34, 2000, 4, RoundDown, TaxSplit{12_34, 2_46, 14_80, -8000})
+	testTax(TaxExclusive, -12_34, 2000, 4, RoundHalfUp, TaxSplit{-12_34, -2_47, -14_81, -2000})
+	testTax(TaxExclusive, 10_00, 770, 4, RoundExact, TaxSplit{10_00, 77, 10_77, 0})
+	testTax(TaxExclusive, 0, 2000, 4, RoundExact, TaxSplit{})
+	testTax(TaxExclusive, 1, 1, 18, RoundUp, TaxSplit{1, 1, 2, 999_999_999_999_999_999})
+	testTax(TaxExclusive, -1, 1, 18, RoundUp, TaxSplit{-1, -1, -2, -999_999_999_999_999_999})
+
+	testTaxErr(TaxExclusive, 12_34, 2000, 4, RoundExact, `result requires rounding`, ErrPrecision)
+	testTaxErr(TaxExclusive, math.MaxInt64, 1, 0, RoundExact, `overflow of int64`, ErrRange)
+	testTaxErr(TaxExclusive, 12_34, 1, 19, RoundDown, `rate precision 19 exceeds limit 18`, nil)
+}
+
+func TestTaxInclusive(*testing.T) {
+	testTax(TaxInclusive, 14_81, 2000, 4, RoundHalfUp, TaxSplit{12_34, 2_47, 14_81, 1666})
+	testTax(TaxInclusive, 14_81, 2000, 4, RoundDown, TaxSplit{12_35, 2_46, 14_81, -8333})
+	testTax(TaxInclusive, -14_81, 2000, 4, RoundHalfUp, TaxSplit{-12_34, -2_47, -14_81, -1666})
+	testTax(TaxInclusive, 12_00, 2000, 4, RoundExact, TaxSplit{10_00, 2_00, 12_00, 0})
+	testTax(TaxInclusive, 12_00, 0, 4, RoundExact, TaxSplit{12_00, 0, 12_00, 0})
+	testTax(TaxInclusive, math.MaxInt64, math.MaxInt64, 0, RoundDown, TaxSplit{1, math.MaxInt64 - 1, math.MaxInt64, 0})
+	testTax(TaxInclusive, math.MinInt64, 1, 18, RoundHalfEven, TaxSplit{math.MinInt64 + 9, -9, math.MinInt64, 223_372_036_854_775_798})
+
+	testTaxErr(TaxInclusive, 14_81, 2000, 4, RoundExact, `result requires rounding`, ErrPrecision)
+	testTaxErr(TaxInclusive, 14_81, -1, 4, RoundExact, `negative rate -1`, nil)
+	testTaxErr(TaxInclusive, 14_81, 1, 19, RoundExact, `rate precision 19 exceeds limit 18`, nil)
+	testTaxErr(TaxInclusive, 14_81, 1, 0, RoundHalfEven+1, `unsupported rounding mode`, nil)
+}
+
+func TestTaxPerLine(*testing.T) {
+	// Each line has 0.5 cents of tax, rounded up per line.
+	total, adj, err := TaxPerLine([]int64{2_50, 2_50, 2_50}, 2000, 4, RoundHalfUp)
+	if err != nil {
+		panic(err)
+	}
+	testEqual(total, TaxSplit{7_50, 1_50, 9_00, 0})
+	testEqual(adj, int64(0))
+
+	total, adj, err = TaxPerLine([]int64{1_01, 1_01, 1_01}, 500, 4, RoundHalfUp)
+	if err != nil {
+		panic(err)
+	}
+	testEqual(total, TaxSplit{3_03, 15, 3_18, -1500})
+	testEqual(adj, int64(0))
+
+	total, adj, err = TaxPerLine([]int64{10, 10, 10}, 500, 4, RoundHalfUp)
+	if err != nil {
+		panic(err)
+	}
+	testEqual(total, TaxSplit{30, 3, 33, 15000})
+	testEqual(adj, int64(1))
+
+	total, adj, err = TaxPerLine(nil, 500, 4, RoundHalfUp)
+	if err != nil {
+		panic(err)
+	}
+	testEqual(total, TaxSplit{})
+	testEqual(adj, int64(0))
+
+	_, _, err = TaxPerLine([]int64{math.MaxInt64, 1}, 0, 4, RoundExact)
+	if !errors.Is(err, ErrRange) {
+		panic(fmt.Errorf(`expected ErrRange, got %v`, err))
+	}
+	_, _, err = TaxPerLine([]int64{1}, 500, 4, RoundExact)
+	if !errors.Is(err, ErrPrecision) {
+		panic(fmt.Errorf(`expected ErrPrecision, got %v`, err))
+	}
+}
+
+func TestDiscount(*testing.T) {
+	testDiscount(19_99, 1500, 4, RoundHalfUp, 16_99, 3_00, 1500)
+	testDiscount(19_99, 1500, 4, RoundDown, 17_00, 2_99, -8500)
+	testDiscount(-19_99, 1500, 4, RoundHalfUp, -16_99, -3_00, -1500)
+	testDiscount(20_00, 10000, 4, RoundExact, 0, 20_00, 0)
+
+	_, _, _, err := Discount(19_99, 1500, 4, RoundExact)
+	if !errors.Is(err, ErrPrecision) {
+		panic(fmt.Errorf(`expected ErrPrecision, got %v`, err))
+	}
+	_, _, _, err = Discount(math.MinInt64, -1, 0, RoundExact)
+	if !errors.Is(err, ErrRange) {
+		panic(fmt.Errorf(`expected ErrRange, got %v`, err))
+	}
+}
+
+func TestRoundTo(*testing.T) {
+	testRoundTo(12_34, 5, RoundHalfUp, 12_35, 1)
+	testRoundTo(12_32, 5, RoundHalfUp, 12_30, -2)
+	testRoundTo(12_325, 50, RoundHalfUp, 12_350, 25)
+	testRoundTo(12_325, 50, RoundHalfEven, 12_300, -25)
+	testRoundTo(-12_34, 5, RoundHalfUp, -12_35, -1)
+	testRoundTo(-12_34, 5, RoundCeil, -12_30, 4)
+	testRoundTo(12_35, 5, RoundExact, 12_35, 0)
+	testRoundTo(0, 5, RoundUp, 0, 0)
+	testRoundTo(7, 1, RoundExact, 7, 0)
+	testRoundTo(math.MinInt64, 2, RoundExact, math.MinInt64, 0)
+	testRoundTo(math.MaxInt64, math.MaxInt64, RoundExact, math.MaxInt64, 0)
+	testRoundTo(math.MinInt64, math.MaxInt64, RoundDown, -math.MaxInt64, 1)
+
+	testRoundToErr(12_34, 5, RoundExact, `not a multiple of increment`, ErrPrecision)
+	testRoundToErr(math.MaxInt64, 2, RoundUp, `overflow of int64`, ErrRange)
+	testRoundToErr(math.MinInt64, math.MaxInt64, RoundUp, `overflow of int64`, ErrRange)
+	testRoundToErr(1, 0, RoundUp, `increment must be positive`, nil)
+	testRoundToErr(1, -5, RoundUp, `increment must be positive`, nil)
+	testRoundToErr(1, 5, RoundHalfEven+1, `unsupported rounding mode`, nil)
+}
+
+func testTax(fun func(int64, int64, uint, RoundingMode) (TaxSplit, error), amount int64, rate int64, rateFrac uint, mode RoundingMode, exp TaxSplit) {
+	act, err := fun(amount, rate, rateFrac, mode)
+	if err != nil {
+		panic(fmt.Errorf(`failed to compute tax on %v at rate %v (frac %v) with %v: %+v`, amount, rate, rateFrac, mode, err))
+	}
+	if act != exp {
+		panic(fmt.Errorf(`expected tax on %v at rate %v (frac %v) with %v to be %+v, got %+v`, amount, rate, rateFrac, mode, exp, act))
+	}
+}
+
+func testTaxErr(fun func(int64, int64, uint, RoundingMode) (TaxSplit, error), amount int64, rate int64, rateFrac uint, mode RoundingMode, msg string, kind error) {
+	res, err := fun(amount, rate, rateFrac, mode)
+	if err == nil {
+		panic(fmt.Errorf(`expected tax on %v at rate %v (frac %v) to fail; instead got %+v`, amount, rate, rateFrac, res))
+	}
+	if !strings.Contains(err.Error(), msg) || (kind != nil && !errors.Is(err, kind)) {
+		panic(fmt.Errorf(`expected error from tax on %v at rate %v (frac %v) to contain %q, got %q`, amount, rate, rateFrac, msg, err))
+	}
+}
+
+func testDiscount(amount int64, rate int64, rateFrac uint, mode RoundingMode, expDiscounted int64, expDiscount int64, expAdj int64) {
+	discounted, discount, adj, err := Discount(amount, rate, rateFrac, mode)
+	if err != nil {
+		panic(err)
+	}
+	if discounted != expDiscounted || discount != expDiscount || adj != expAdj {
+		panic(fmt.Errorf(
+			`expected discounting %v by rate %v with %v to produce %v and %v with adjustment %v, got %v and %v with adjustment %v`,
+			amount, rate, mode, expDiscounted, expDiscount, expAdj, discounted, discount, adj,
+		))
+	}
+}
+
+func testRoundTo(num int64, increment int64, mode RoundingMode, expRounded int64, expAdj int64) {
+	rounded, adj, err := RoundTo(num, increment, mode)
+	if err != nil {
+		panic(fmt.Errorf(`failed to round %v to %v with %v: %+v`, num, increment, mode, err))
+	}
+	if rounded != expRounded || adj != expAdj {
+		panic(fmt.Errorf(`expected rounding %v to %v with %v to produce %v with adjustment %v, got %v with adjustment %v`, num, increment, mode, expRounded, expAdj, rounded, adj))
+	}
+}
+
+func testRoundToErr(num int64, increment int64, mode RoundingMode, msg string, kind error) {
+	rounded, adj, err := RoundTo(num, increment, mode)
+	if err == nil {
+		panic(fmt.Errorf(`expected rounding %v to %v to fail; instead got %v, %v`, num, increment, rounded, adj))
+	}
+	if !strings.Contains(err.Error(), msg) || (kind != nil && !errors.Is(err, kind)) {
+		panic(fmt.Errorf(`expected error from rounding %v to %v to contain %q, got %q`, num, increment, msg, err))
+	}
+}